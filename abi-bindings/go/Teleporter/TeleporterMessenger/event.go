@@ -22,6 +22,7 @@ const (
 	MessageExecuted
 	RelayerRewardsRedeemed
 	ReceiptReceived
+	BlockchainIDInitialized
 
 	sendCrossChainMessageStr    = "SendCrossChainMessage"
 	receiveCrossChainMessageStr = "ReceiveCrossChainMessage"
@@ -30,6 +31,7 @@ const (
 	messageExecutedStr          = "MessageExecuted"
 	relayerRewardsRedeemedStr   = "RelayerRewardsRedeemed"
 	receiptReceivedStr          = "ReceiptReceived"
+	blockchainIDInitializedStr  = "BlockchainIDInitialized"
 	unknownStr                  = "Unknown"
 )
 
@@ -50,6 +52,8 @@ func (e Event) String() string {
 		return relayerRewardsRedeemedStr
 	case ReceiptReceived:
 		return receiptReceivedStr
+	case BlockchainIDInitialized:
+		return blockchainIDInitializedStr
 	default:
 		return unknownStr
 	}
@@ -72,6 +76,8 @@ func ToEvent(e string) (Event, error) {
 		return RelayerRewardsRedeemed, nil
 	case strings.ToLower(receiptReceivedStr):
 		return ReceiptReceived, nil
+	case strings.ToLower(blockchainIDInitializedStr):
+		return BlockchainIDInitialized, nil
 	default:
 		return Unknown, fmt.Errorf("unknown event %s", e)
 	}
@@ -99,6 +105,8 @@ func FilterTeleporterEvents(topics []common.Hash, data []byte, event string) (in
 		out = new(TeleporterMessengerRelayerRewardsRedeemed)
 	case ReceiptReceived:
 		out = new(TeleporterMessengerReceiptReceived)
+	case BlockchainIDInitialized:
+		out = new(TeleporterMessengerBlockchainIDInitialized)
 	default:
 		return nil, fmt.Errorf("unknown event %s", e.String())
 	}
@@ -0,0 +1,285 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNoTopics     = errors.New("log has no topics")
+	ErrUnknownEvent = errors.New("unknown Teleporter event")
+)
+
+// eventsByID maps the topic of each event of ITeleporterMessenger to the Event.
+var eventsByID map[common.Hash]Event
+
+func init() {
+	abi, err := TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get TeleporterMessenger ABI: %v", err))
+	}
+	eventsByID = make(map[common.Hash]Event)
+	for _, e := range []Event{
+		SendCrossChainMessage,
+		ReceiveCrossChainMessage,
+		AddFeeAmount,
+		MessageExecutionFailed,
+		MessageExecuted,
+		RelayerRewardsRedeemed,
+		ReceiptReceived,
+		BlockchainIDInitialized,
+	} {
+		event, ok := abi.Events[e.String()]
+		if !ok {
+			panic(fmt.Sprintf("TeleporterMessenger ABI has no %s event", e))
+		}
+		eventsByID[event.ID] = e
+	}
+}
+
+// TeleporterEvent is a decoded Teleporter log, returned by ParseLog.
+type TeleporterEvent interface {
+	// Kind returns the type of the event.
+	Kind() Event
+	// MessageID returns the ID of the message the event refers to, or ids.Empty if it refers to none.
+	MessageID() ids.ID
+	// BlockchainIDs returns the blockchain IDs of the message the event refers to that are included
+	// in the event.
+	BlockchainIDs() EventBlockchainIDs
+	// Metadata returns the position of the log the event was decoded from.
+	Metadata() LogMetadata
+}
+
+// EventBlockchainIDs are the source and destination blockchain IDs of the message a Teleporter event
+// refers to. IDs that are not included in the event are ids.Empty. The blockchain that emitted the
+// log is not included unless it is part of the event, e.g. the source blockchain of a
+// SendCrossChainMessage event is the blockchain of the log, and is empty.
+type EventBlockchainIDs struct {
+	Source      ids.ID
+	Destination ids.ID
+}
+
+// LogMetadata is the position of a log in the chain.
+type LogMetadata struct {
+	Address     common.Address
+	BlockNumber uint64
+	BlockHash   common.Hash
+	TxHash      common.Hash
+	TxIndex     uint
+	LogIndex    uint
+	Removed     bool
+}
+
+func newLogMetadata(log types.Log) LogMetadata {
+	return LogMetadata{
+		Address:     log.Address,
+		BlockNumber: log.BlockNumber,
+		BlockHash:   log.BlockHash,
+		TxHash:      log.TxHash,
+		TxIndex:     log.TxIndex,
+		LogIndex:    log.Index,
+		Removed:     log.Removed,
+	}
+}
+
+var (
+	_ TeleporterEvent = &SendCrossChainMessageEvent{}
+	_ TeleporterEvent = &ReceiveCrossChainMessageEvent{}
+	_ TeleporterEvent = &AddFeeAmountEvent{}
+	_ TeleporterEvent = &MessageExecutionFailedEvent{}
+	_ TeleporterEvent = &MessageExecutedEvent{}
+	_ TeleporterEvent = &RelayerRewardsRedeemedEvent{}
+	_ TeleporterEvent = &ReceiptReceivedEvent{}
+	_ TeleporterEvent = &BlockchainIDInitializedEvent{}
+)
+
+// SendCrossChainMessageEvent is a decoded SendCrossChainMessage log.
+type SendCrossChainMessageEvent struct {
+	TeleporterMessengerSendCrossChainMessage
+}
+
+func (e *SendCrossChainMessageEvent) Kind() Event { return SendCrossChainMessage }
+
+func (e *SendCrossChainMessageEvent) MessageID() ids.ID {
+	return e.TeleporterMessengerSendCrossChainMessage.MessageID
+}
+
+func (e *SendCrossChainMessageEvent) BlockchainIDs() EventBlockchainIDs {
+	return EventBlockchainIDs{Destination: e.DestinationBlockchainID}
+}
+
+func (e *SendCrossChainMessageEvent) Metadata() LogMetadata { return newLogMetadata(e.Raw) }
+
+// ReceiveCrossChainMessageEvent is a decoded ReceiveCrossChainMessage log.
+type ReceiveCrossChainMessageEvent struct {
+	TeleporterMessengerReceiveCrossChainMessage
+}
+
+func (e *ReceiveCrossChainMessageEvent) Kind() Event { return ReceiveCrossChainMessage }
+
+func (e *ReceiveCrossChainMessageEvent) MessageID() ids.ID {
+	return e.TeleporterMessengerReceiveCrossChainMessage.MessageID
+}
+
+func (e *ReceiveCrossChainMessageEvent) BlockchainIDs() EventBlockchainIDs {
+	return EventBlockchainIDs{Source: e.SourceBlockchainID, Destination: e.Message.DestinationBlockchainID}
+}
+
+func (e *ReceiveCrossChainMessageEvent) Metadata() LogMetadata { return newLogMetadata(e.Raw) }
+
+// AddFeeAmountEvent is a decoded AddFeeAmount log.
+type AddFeeAmountEvent struct {
+	TeleporterMessengerAddFeeAmount
+}
+
+func (e *AddFeeAmountEvent) Kind() Event { return AddFeeAmount }
+
+func (e *AddFeeAmountEvent) MessageID() ids.ID { return e.TeleporterMessengerAddFeeAmount.MessageID }
+
+func (e *AddFeeAmountEvent) BlockchainIDs() EventBlockchainIDs { return EventBlockchainIDs{} }
+
+func (e *AddFeeAmountEvent) Metadata() LogMetadata { return newLogMetadata(e.Raw) }
+
+// MessageExecutionFailedEvent is a decoded MessageExecutionFailed log.
+type MessageExecutionFailedEvent struct {
+	TeleporterMessengerMessageExecutionFailed
+}
+
+func (e *MessageExecutionFailedEvent) Kind() Event { return MessageExecutionFailed }
+
+func (e *MessageExecutionFailedEvent) MessageID() ids.ID {
+	return e.TeleporterMessengerMessageExecutionFailed.MessageID
+}
+
+func (e *MessageExecutionFailedEvent) BlockchainIDs() EventBlockchainIDs {
+	return EventBlockchainIDs{Source: e.SourceBlockchainID, Destination: e.Message.DestinationBlockchainID}
+}
+
+func (e *MessageExecutionFailedEvent) Metadata() LogMetadata { return newLogMetadata(e.Raw) }
+
+// MessageExecutedEvent is a decoded MessageExecuted log.
+type MessageExecutedEvent struct {
+	TeleporterMessengerMessageExecuted
+}
+
+func (e *MessageExecutedEvent) Kind() Event { return MessageExecuted }
+
+func (e *MessageExecutedEvent) MessageID() ids.ID {
+	return e.TeleporterMessengerMessageExecuted.MessageID
+}
+
+func (e *MessageExecutedEvent) BlockchainIDs() EventBlockchainIDs {
+	return EventBlockchainIDs{Source: e.SourceBlockchainID}
+}
+
+func (e *MessageExecutedEvent) Metadata() LogMetadata { return newLogMetadata(e.Raw) }
+
+// RelayerRewardsRedeemedEvent is a decoded RelayerRewardsRedeemed log. It refers to no message.
+type RelayerRewardsRedeemedEvent struct {
+	TeleporterMessengerRelayerRewardsRedeemed
+}
+
+func (e *RelayerRewardsRedeemedEvent) Kind() Event { return RelayerRewardsRedeemed }
+
+func (e *RelayerRewardsRedeemedEvent) MessageID() ids.ID { return ids.Empty }
+
+func (e *RelayerRewardsRedeemedEvent) BlockchainIDs() EventBlockchainIDs { return EventBlockchainIDs{} }
+
+func (e *RelayerRewardsRedeemedEvent) Metadata() LogMetadata { return newLogMetadata(e.Raw) }
+
+// ReceiptReceivedEvent is a decoded ReceiptReceived log.
+type ReceiptReceivedEvent struct {
+	TeleporterMessengerReceiptReceived
+}
+
+func (e *ReceiptReceivedEvent) Kind() Event { return ReceiptReceived }
+
+func (e *ReceiptReceivedEvent) MessageID() ids.ID {
+	return e.TeleporterMessengerReceiptReceived.MessageID
+}
+
+func (e *ReceiptReceivedEvent) BlockchainIDs() EventBlockchainIDs {
+	return EventBlockchainIDs{Destination: e.DestinationBlockchainID}
+}
+
+func (e *ReceiptReceivedEvent) Metadata() LogMetadata { return newLogMetadata(e.Raw) }
+
+// BlockchainIDInitializedEvent is a decoded BlockchainIDInitialized log. It refers to no message,
+// and its blockchain ID is that of the blockchain that emitted the log.
+type BlockchainIDInitializedEvent struct {
+	TeleporterMessengerBlockchainIDInitialized
+}
+
+func (e *BlockchainIDInitializedEvent) Kind() Event { return BlockchainIDInitialized }
+
+func (e *BlockchainIDInitializedEvent) MessageID() ids.ID { return ids.Empty }
+
+// BlockchainIDs returns the initialized blockchain ID as the destination, since it is the blockchain
+// that the messages received by the contract that emitted the log are delivered to.
+func (e *BlockchainIDInitializedEvent) BlockchainIDs() EventBlockchainIDs {
+	return EventBlockchainIDs{Destination: e.BlockchainID}
+}
+
+func (e *BlockchainIDInitializedEvent) Metadata() LogMetadata { return newLogMetadata(e.Raw) }
+
+// ParseLog decodes a log emitted by the Teleporter contract into the event identified by its first
+// topic. The log is kept in the Raw field of the event.
+func ParseLog(log types.Log) (TeleporterEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrNoTopics
+	}
+	kind, ok := eventsByID[log.Topics[0]]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownEvent, "topic %s", log.Topics[0].Hex())
+	}
+
+	var (
+		event TeleporterEvent
+		out   interface{}
+	)
+	switch kind {
+	case SendCrossChainMessage:
+		e := new(SendCrossChainMessageEvent)
+		event, out = e, &e.TeleporterMessengerSendCrossChainMessage
+		e.Raw = log
+	case ReceiveCrossChainMessage:
+		e := new(ReceiveCrossChainMessageEvent)
+		event, out = e, &e.TeleporterMessengerReceiveCrossChainMessage
+		e.Raw = log
+	case AddFeeAmount:
+		e := new(AddFeeAmountEvent)
+		event, out = e, &e.TeleporterMessengerAddFeeAmount
+		e.Raw = log
+	case MessageExecutionFailed:
+		e := new(MessageExecutionFailedEvent)
+		event, out = e, &e.TeleporterMessengerMessageExecutionFailed
+		e.Raw = log
+	case MessageExecuted:
+		e := new(MessageExecutedEvent)
+		event, out = e, &e.TeleporterMessengerMessageExecuted
+		e.Raw = log
+	case RelayerRewardsRedeemed:
+		e := new(RelayerRewardsRedeemedEvent)
+		event, out = e, &e.TeleporterMessengerRelayerRewardsRedeemed
+		e.Raw = log
+	case ReceiptReceived:
+		e := new(ReceiptReceivedEvent)
+		event, out = e, &e.TeleporterMessengerReceiptReceived
+		e.Raw = log
+	case BlockchainIDInitialized:
+		e := new(BlockchainIDInitializedEvent)
+		event, out = e, &e.TeleporterMessengerBlockchainIDInitialized
+		e.Raw = log
+	}
+	if err := UnpackEvent(out, kind.String(), log.Topics, log.Data); err != nil {
+		return nil, errors.Wrapf(err, "failed to unpack %s event", kind)
+	}
+	return event, nil
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLog(t *testing.T) {
+	mockSourceBlockchainID := ids.ID{5, 6, 7, 8}
+	mockMessageID := ids.ID{9, 10, 11, 12}
+	message := createTestTeleporterMessage(big.NewInt(8))
+	feeInfo := TeleporterFeeInfo{
+		FeeTokenAddress: common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567"),
+		Amount:          big.NewInt(1),
+	}
+	relayer := common.HexToAddress("0x76543210fedcba9876543210fedcba9876543210")
+
+	teleporterABI, err := TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	var (
+		tests = []struct {
+			event         Event
+			args          []interface{}
+			messageID     ids.ID
+			blockchainIDs EventBlockchainIDs
+		}{
+			{
+				event:         SendCrossChainMessage,
+				args:          []interface{}{mockMessageID, message.DestinationBlockchainID, message, feeInfo},
+				messageID:     mockMessageID,
+				blockchainIDs: EventBlockchainIDs{Destination: message.DestinationBlockchainID},
+			},
+			{
+				event:     ReceiveCrossChainMessage,
+				args:      []interface{}{mockMessageID, mockSourceBlockchainID, relayer, relayer, message},
+				messageID: mockMessageID,
+				blockchainIDs: EventBlockchainIDs{
+					Source:      mockSourceBlockchainID,
+					Destination: message.DestinationBlockchainID,
+				},
+			},
+			{
+				event:     AddFeeAmount,
+				args:      []interface{}{mockMessageID, feeInfo},
+				messageID: mockMessageID,
+			},
+			{
+				event:     MessageExecutionFailed,
+				args:      []interface{}{mockMessageID, mockSourceBlockchainID, message},
+				messageID: mockMessageID,
+				blockchainIDs: EventBlockchainIDs{
+					Source:      mockSourceBlockchainID,
+					Destination: message.DestinationBlockchainID,
+				},
+			},
+			{
+				event:         MessageExecuted,
+				args:          []interface{}{mockMessageID, mockSourceBlockchainID},
+				messageID:     mockMessageID,
+				blockchainIDs: EventBlockchainIDs{Source: mockSourceBlockchainID},
+			},
+			{
+				event: RelayerRewardsRedeemed,
+				args:  []interface{}{relayer, feeInfo.FeeTokenAddress, big.NewInt(3)},
+			},
+			{
+				event:         ReceiptReceived,
+				args:          []interface{}{mockMessageID, mockSourceBlockchainID, relayer, feeInfo},
+				messageID:     mockMessageID,
+				blockchainIDs: EventBlockchainIDs{Destination: mockSourceBlockchainID},
+			},
+			{
+				// The initialized blockchain ID is that of the blockchain messages are delivered to.
+				event:         BlockchainIDInitialized,
+				args:          []interface{}{mockSourceBlockchainID},
+				blockchainIDs: EventBlockchainIDs{Destination: mockSourceBlockchainID},
+			},
+		}
+	)
+
+	for _, test := range tests {
+		t.Run(test.event.String(), func(t *testing.T) {
+			topics, data, err := teleporterABI.PackEvent(test.event.String(), test.args...)
+			require.NoError(t, err)
+			log := types.Log{
+				Address:     common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"),
+				Topics:      topics,
+				Data:        data,
+				BlockNumber: 100,
+				TxHash:      common.HexToHash("0x01"),
+				Index:       2,
+			}
+
+			event, err := ParseLog(log)
+			require.NoError(t, err)
+			require.Equal(t, test.event, event.Kind())
+			require.Equal(t, test.messageID, event.MessageID())
+			require.Equal(t, test.blockchainIDs, event.BlockchainIDs())
+			require.Equal(t, LogMetadata{
+				Address:     log.Address,
+				BlockNumber: log.BlockNumber,
+				TxHash:      log.TxHash,
+				LogIndex:    log.Index,
+			}, event.Metadata())
+
+			// The decoded fields match those of the abigen event.
+			expected, err := FilterTeleporterEvents(topics, data, test.event.String())
+			require.NoError(t, err)
+			require.Equal(t, expected, eventFields(t, event))
+
+			// The JSON encoding extends that of the abigen event with the metadata of the log.
+			b, err := json.Marshal(event)
+			require.NoError(t, err)
+			var encoded map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(b, &encoded))
+			require.JSONEq(t, `{
+				"address": "0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf",
+				"blockNumber": 100,
+				"blockHash": "`+common.Hash{}.Hex()+`",
+				"txHash": "`+log.TxHash.Hex()+`",
+				"txIndex": 0,
+				"logIndex": 2,
+				"removed": false
+			}`, string(encoded["metadata"]))
+
+			decoded := reflect.New(reflect.TypeOf(event).Elem()).Interface().(TeleporterEvent)
+			require.NoError(t, json.Unmarshal(b, decoded))
+			require.Equal(t, event.Metadata(), decoded.Metadata())
+			require.Equal(t, expected, eventFields(t, decoded))
+		})
+	}
+}
+
+func TestParseLogErrors(t *testing.T) {
+	_, err := ParseLog(types.Log{})
+	require.ErrorIs(t, err, ErrNoTopics)
+
+	_, err = ParseLog(types.Log{Topics: []common.Hash{{1}}})
+	require.ErrorIs(t, err, ErrUnknownEvent)
+}
+
+// eventFields returns the abigen event embedded in event, without its Raw log.
+func eventFields(t *testing.T, event TeleporterEvent) interface{} {
+	switch e := event.(type) {
+	case *SendCrossChainMessageEvent:
+		out := e.TeleporterMessengerSendCrossChainMessage
+		out.Raw = types.Log{}
+		return &out
+	case *ReceiveCrossChainMessageEvent:
+		out := e.TeleporterMessengerReceiveCrossChainMessage
+		out.Raw = types.Log{}
+		return &out
+	case *AddFeeAmountEvent:
+		out := e.TeleporterMessengerAddFeeAmount
+		out.Raw = types.Log{}
+		return &out
+	case *MessageExecutionFailedEvent:
+		out := e.TeleporterMessengerMessageExecutionFailed
+		out.Raw = types.Log{}
+		return &out
+	case *MessageExecutedEvent:
+		out := e.TeleporterMessengerMessageExecuted
+		out.Raw = types.Log{}
+		return &out
+	case *RelayerRewardsRedeemedEvent:
+		out := e.TeleporterMessengerRelayerRewardsRedeemed
+		out.Raw = types.Log{}
+		return &out
+	case *ReceiptReceivedEvent:
+		out := e.TeleporterMessengerReceiptReceived
+		out.Raw = types.Log{}
+		return &out
+	case *BlockchainIDInitializedEvent:
+		out := e.TeleporterMessengerBlockchainIDInitialized
+		out.Raw = types.Log{}
+		return &out
+	}
+	t.Fatalf("unexpected event type %T", event)
+	return nil
+}
@@ -25,6 +25,8 @@ func TestEventString(t *testing.T) {
 			{MessageExecutionFailed, messageExecutionFailedStr},
 			{MessageExecuted, messageExecutedStr},
 			{RelayerRewardsRedeemed, relayerRewardsRedeemedStr},
+			{ReceiptReceived, receiptReceivedStr},
+			{BlockchainIDInitialized, blockchainIDInitializedStr},
 		}
 	)
 
@@ -49,6 +51,8 @@ func TestToEvent(t *testing.T) {
 			{messageExecutionFailedStr, MessageExecutionFailed, false},
 			{messageExecutedStr, MessageExecuted, false},
 			{relayerRewardsRedeemedStr, RelayerRewardsRedeemed, false},
+			{receiptReceivedStr, ReceiptReceived, false},
+			{blockchainIDInitializedStr, BlockchainIDInitialized, false},
 		}
 	)
 
@@ -0,0 +1,575 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Canonical JSON encodings of the Teleporter structs and events. Blockchain IDs are CB58 encoded,
+// message IDs are 0x prefixed hex, addresses are checksummed hex, uint256 integers are decimal
+// strings and byte strings are 0x prefixed hex. The Raw log of the abigen events is not encoded,
+// while the events returned by ParseLog also encode the position of their log as its metadata.
+// Text encodings are only defined for scalar values, such as Event, so that encoders that prefer
+// encoding.TextMarshaler, such as YAML encoders, still encode the structs and events as maps.
+
+// jsonBigInt is a uint256 encoded as a decimal string. All integers of the Teleporter structs and
+// events are uint256, which the ABI packer would encode negative values of as two's complement.
+type jsonBigInt big.Int
+
+func newJSONBigInt(n *big.Int) *jsonBigInt {
+	return (*jsonBigInt)(n)
+}
+
+func (n *jsonBigInt) MarshalText() ([]byte, error) {
+	return []byte((*big.Int)(n).String()), nil
+}
+
+func (n *jsonBigInt) UnmarshalText(text []byte) error {
+	if _, ok := (*big.Int)(n).SetString(string(text), 10); !ok {
+		return fmt.Errorf("invalid decimal integer %q", text)
+	}
+	if (*big.Int)(n).Sign() < 0 || (*big.Int)(n).BitLen() > 256 {
+		return fmt.Errorf("invalid uint256 %q", text)
+	}
+	return nil
+}
+
+// jsonAddress is an address encoded as checksummed hex.
+type jsonAddress common.Address
+
+func (a jsonAddress) MarshalText() ([]byte, error) {
+	return []byte(common.Address(a).Hex()), nil
+}
+
+func (a *jsonAddress) UnmarshalText(text []byte) error {
+	if !common.IsHexAddress(string(text)) {
+		return fmt.Errorf("invalid address %q", text)
+	}
+	*a = jsonAddress(common.HexToAddress(string(text)))
+	return nil
+}
+
+func toJSONAddresses(addresses []common.Address) []jsonAddress {
+	if addresses == nil {
+		return nil
+	}
+	out := make([]jsonAddress, len(addresses))
+	for i, address := range addresses {
+		out[i] = jsonAddress(address)
+	}
+	return out
+}
+
+func fromJSONAddresses(addresses []jsonAddress) []common.Address {
+	if addresses == nil {
+		return nil
+	}
+	out := make([]common.Address, len(addresses))
+	for i, address := range addresses {
+		out[i] = common.Address(address)
+	}
+	return out
+}
+
+// MarshalText encodes the event as its name. Values other than Unknown and the defined events
+// have no name, and are rejected rather than being encoded as Unknown.
+func (e Event) MarshalText() ([]byte, error) {
+	if e > BlockchainIDInitialized {
+		return nil, fmt.Errorf("invalid event %d", uint8(e))
+	}
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText decodes an event from its name, case insensitively. Unlike ToEvent, it accepts the
+// name of Unknown, so that every encoded event decodes.
+func (e *Event) UnmarshalText(text []byte) error {
+	if strings.EqualFold(string(text), unknownStr) {
+		*e = Unknown
+		return nil
+	}
+	event, err := ToEvent(string(text))
+	if err != nil {
+		return err
+	}
+	*e = event
+	return nil
+}
+
+type teleporterFeeInfoJSON struct {
+	FeeTokenAddress jsonAddress `json:"feeTokenAddress"`
+	Amount          *jsonBigInt `json:"amount"`
+}
+
+func (f TeleporterFeeInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(teleporterFeeInfoJSON{
+		FeeTokenAddress: jsonAddress(f.FeeTokenAddress),
+		Amount:          newJSONBigInt(f.Amount),
+	})
+}
+
+func (f *TeleporterFeeInfo) UnmarshalJSON(b []byte) error {
+	var dec teleporterFeeInfoJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	f.FeeTokenAddress = common.Address(dec.FeeTokenAddress)
+	f.Amount = (*big.Int)(dec.Amount)
+	return nil
+}
+
+type teleporterMessageReceiptJSON struct {
+	ReceivedMessageNonce *jsonBigInt `json:"receivedMessageNonce"`
+	RelayerRewardAddress jsonAddress `json:"relayerRewardAddress"`
+}
+
+func (r TeleporterMessageReceipt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(teleporterMessageReceiptJSON{
+		ReceivedMessageNonce: newJSONBigInt(r.ReceivedMessageNonce),
+		RelayerRewardAddress: jsonAddress(r.RelayerRewardAddress),
+	})
+}
+
+func (r *TeleporterMessageReceipt) UnmarshalJSON(b []byte) error {
+	var dec teleporterMessageReceiptJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	r.ReceivedMessageNonce = (*big.Int)(dec.ReceivedMessageNonce)
+	r.RelayerRewardAddress = common.Address(dec.RelayerRewardAddress)
+	return nil
+}
+
+type teleporterMessageJSON struct {
+	MessageNonce            *jsonBigInt                `json:"messageNonce"`
+	OriginSenderAddress     jsonAddress                `json:"originSenderAddress"`
+	DestinationBlockchainID ids.ID                     `json:"destinationBlockchainID"`
+	DestinationAddress      jsonAddress                `json:"destinationAddress"`
+	RequiredGasLimit        *jsonBigInt                `json:"requiredGasLimit"`
+	AllowedRelayerAddresses []jsonAddress              `json:"allowedRelayerAddresses"`
+	Receipts                []TeleporterMessageReceipt `json:"receipts"`
+	Message                 hexutil.Bytes              `json:"message"`
+}
+
+func (m TeleporterMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(teleporterMessageJSON{
+		MessageNonce:            newJSONBigInt(m.MessageNonce),
+		OriginSenderAddress:     jsonAddress(m.OriginSenderAddress),
+		DestinationBlockchainID: m.DestinationBlockchainID,
+		DestinationAddress:      jsonAddress(m.DestinationAddress),
+		RequiredGasLimit:        newJSONBigInt(m.RequiredGasLimit),
+		AllowedRelayerAddresses: toJSONAddresses(m.AllowedRelayerAddresses),
+		Receipts:                m.Receipts,
+		Message:                 m.Message,
+	})
+}
+
+func (m *TeleporterMessage) UnmarshalJSON(b []byte) error {
+	var dec teleporterMessageJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	m.MessageNonce = (*big.Int)(dec.MessageNonce)
+	m.OriginSenderAddress = common.Address(dec.OriginSenderAddress)
+	m.DestinationBlockchainID = dec.DestinationBlockchainID
+	m.DestinationAddress = common.Address(dec.DestinationAddress)
+	m.RequiredGasLimit = (*big.Int)(dec.RequiredGasLimit)
+	m.AllowedRelayerAddresses = fromJSONAddresses(dec.AllowedRelayerAddresses)
+	m.Receipts = dec.Receipts
+	m.Message = dec.Message
+	return nil
+}
+
+type teleporterMessageInputJSON struct {
+	DestinationBlockchainID ids.ID            `json:"destinationBlockchainID"`
+	DestinationAddress      jsonAddress       `json:"destinationAddress"`
+	FeeInfo                 TeleporterFeeInfo `json:"feeInfo"`
+	RequiredGasLimit        *jsonBigInt       `json:"requiredGasLimit"`
+	AllowedRelayerAddresses []jsonAddress     `json:"allowedRelayerAddresses"`
+	Message                 hexutil.Bytes     `json:"message"`
+}
+
+func (i TeleporterMessageInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(teleporterMessageInputJSON{
+		DestinationBlockchainID: i.DestinationBlockchainID,
+		DestinationAddress:      jsonAddress(i.DestinationAddress),
+		FeeInfo:                 i.FeeInfo,
+		RequiredGasLimit:        newJSONBigInt(i.RequiredGasLimit),
+		AllowedRelayerAddresses: toJSONAddresses(i.AllowedRelayerAddresses),
+		Message:                 i.Message,
+	})
+}
+
+func (i *TeleporterMessageInput) UnmarshalJSON(b []byte) error {
+	var dec teleporterMessageInputJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	i.DestinationBlockchainID = dec.DestinationBlockchainID
+	i.DestinationAddress = common.Address(dec.DestinationAddress)
+	i.FeeInfo = dec.FeeInfo
+	i.RequiredGasLimit = (*big.Int)(dec.RequiredGasLimit)
+	i.AllowedRelayerAddresses = fromJSONAddresses(dec.AllowedRelayerAddresses)
+	i.Message = dec.Message
+	return nil
+}
+
+type sendCrossChainMessageJSON struct {
+	MessageID               common.Hash       `json:"messageID"`
+	DestinationBlockchainID ids.ID            `json:"destinationBlockchainID"`
+	Message                 TeleporterMessage `json:"message"`
+	FeeInfo                 TeleporterFeeInfo `json:"feeInfo"`
+}
+
+func (e TeleporterMessengerSendCrossChainMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sendCrossChainMessageJSON{
+		MessageID:               e.MessageID,
+		DestinationBlockchainID: e.DestinationBlockchainID,
+		Message:                 e.Message,
+		FeeInfo:                 e.FeeInfo,
+	})
+}
+
+func (e *TeleporterMessengerSendCrossChainMessage) UnmarshalJSON(b []byte) error {
+	var dec sendCrossChainMessageJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	e.MessageID = dec.MessageID
+	e.DestinationBlockchainID = dec.DestinationBlockchainID
+	e.Message = dec.Message
+	e.FeeInfo = dec.FeeInfo
+	return nil
+}
+
+type receiveCrossChainMessageJSON struct {
+	MessageID          common.Hash       `json:"messageID"`
+	SourceBlockchainID ids.ID            `json:"sourceBlockchainID"`
+	Deliverer          jsonAddress       `json:"deliverer"`
+	RewardRedeemer     jsonAddress       `json:"rewardRedeemer"`
+	Message            TeleporterMessage `json:"message"`
+}
+
+func (e TeleporterMessengerReceiveCrossChainMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(receiveCrossChainMessageJSON{
+		MessageID:          e.MessageID,
+		SourceBlockchainID: e.SourceBlockchainID,
+		Deliverer:          jsonAddress(e.Deliverer),
+		RewardRedeemer:     jsonAddress(e.RewardRedeemer),
+		Message:            e.Message,
+	})
+}
+
+func (e *TeleporterMessengerReceiveCrossChainMessage) UnmarshalJSON(b []byte) error {
+	var dec receiveCrossChainMessageJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	e.MessageID = dec.MessageID
+	e.SourceBlockchainID = dec.SourceBlockchainID
+	e.Deliverer = common.Address(dec.Deliverer)
+	e.RewardRedeemer = common.Address(dec.RewardRedeemer)
+	e.Message = dec.Message
+	return nil
+}
+
+type addFeeAmountJSON struct {
+	MessageID      common.Hash       `json:"messageID"`
+	UpdatedFeeInfo TeleporterFeeInfo `json:"updatedFeeInfo"`
+}
+
+func (e TeleporterMessengerAddFeeAmount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(addFeeAmountJSON{
+		MessageID:      e.MessageID,
+		UpdatedFeeInfo: e.UpdatedFeeInfo,
+	})
+}
+
+func (e *TeleporterMessengerAddFeeAmount) UnmarshalJSON(b []byte) error {
+	var dec addFeeAmountJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	e.MessageID = dec.MessageID
+	e.UpdatedFeeInfo = dec.UpdatedFeeInfo
+	return nil
+}
+
+type messageExecutionFailedJSON struct {
+	MessageID          common.Hash       `json:"messageID"`
+	SourceBlockchainID ids.ID            `json:"sourceBlockchainID"`
+	Message            TeleporterMessage `json:"message"`
+}
+
+func (e TeleporterMessengerMessageExecutionFailed) MarshalJSON() ([]byte, error) {
+	return json.Marshal(messageExecutionFailedJSON{
+		MessageID:          e.MessageID,
+		SourceBlockchainID: e.SourceBlockchainID,
+		Message:            e.Message,
+	})
+}
+
+func (e *TeleporterMessengerMessageExecutionFailed) UnmarshalJSON(b []byte) error {
+	var dec messageExecutionFailedJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	e.MessageID = dec.MessageID
+	e.SourceBlockchainID = dec.SourceBlockchainID
+	e.Message = dec.Message
+	return nil
+}
+
+type messageExecutedJSON struct {
+	MessageID          common.Hash `json:"messageID"`
+	SourceBlockchainID ids.ID      `json:"sourceBlockchainID"`
+}
+
+func (e TeleporterMessengerMessageExecuted) MarshalJSON() ([]byte, error) {
+	return json.Marshal(messageExecutedJSON{
+		MessageID:          e.MessageID,
+		SourceBlockchainID: e.SourceBlockchainID,
+	})
+}
+
+func (e *TeleporterMessengerMessageExecuted) UnmarshalJSON(b []byte) error {
+	var dec messageExecutedJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	e.MessageID = dec.MessageID
+	e.SourceBlockchainID = dec.SourceBlockchainID
+	return nil
+}
+
+type relayerRewardsRedeemedJSON struct {
+	Redeemer jsonAddress `json:"redeemer"`
+	Asset    jsonAddress `json:"asset"`
+	Amount   *jsonBigInt `json:"amount"`
+}
+
+func (e TeleporterMessengerRelayerRewardsRedeemed) MarshalJSON() ([]byte, error) {
+	return json.Marshal(relayerRewardsRedeemedJSON{
+		Redeemer: jsonAddress(e.Redeemer),
+		Asset:    jsonAddress(e.Asset),
+		Amount:   newJSONBigInt(e.Amount),
+	})
+}
+
+func (e *TeleporterMessengerRelayerRewardsRedeemed) UnmarshalJSON(b []byte) error {
+	var dec relayerRewardsRedeemedJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	e.Redeemer = common.Address(dec.Redeemer)
+	e.Asset = common.Address(dec.Asset)
+	e.Amount = (*big.Int)(dec.Amount)
+	return nil
+}
+
+type receiptReceivedJSON struct {
+	MessageID               common.Hash       `json:"messageID"`
+	DestinationBlockchainID ids.ID            `json:"destinationBlockchainID"`
+	RelayerRewardAddress    jsonAddress       `json:"relayerRewardAddress"`
+	FeeInfo                 TeleporterFeeInfo `json:"feeInfo"`
+}
+
+func (e TeleporterMessengerReceiptReceived) MarshalJSON() ([]byte, error) {
+	return json.Marshal(receiptReceivedJSON{
+		MessageID:               e.MessageID,
+		DestinationBlockchainID: e.DestinationBlockchainID,
+		RelayerRewardAddress:    jsonAddress(e.RelayerRewardAddress),
+		FeeInfo:                 e.FeeInfo,
+	})
+}
+
+func (e *TeleporterMessengerReceiptReceived) UnmarshalJSON(b []byte) error {
+	var dec receiptReceivedJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	e.MessageID = dec.MessageID
+	e.DestinationBlockchainID = dec.DestinationBlockchainID
+	e.RelayerRewardAddress = common.Address(dec.RelayerRewardAddress)
+	e.FeeInfo = dec.FeeInfo
+	return nil
+}
+
+type blockchainIDInitializedJSON struct {
+	BlockchainID ids.ID `json:"blockchainID"`
+}
+
+func (e TeleporterMessengerBlockchainIDInitialized) MarshalJSON() ([]byte, error) {
+	return json.Marshal(blockchainIDInitializedJSON{
+		BlockchainID: e.BlockchainID,
+	})
+}
+
+func (e *TeleporterMessengerBlockchainIDInitialized) UnmarshalJSON(b []byte) error {
+	var dec blockchainIDInitializedJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	e.BlockchainID = dec.BlockchainID
+	return nil
+}
+
+type logMetadataJSON struct {
+	Address     jsonAddress `json:"address"`
+	BlockNumber uint64      `json:"blockNumber"`
+	BlockHash   common.Hash `json:"blockHash"`
+	TxHash      common.Hash `json:"txHash"`
+	TxIndex     uint        `json:"txIndex"`
+	LogIndex    uint        `json:"logIndex"`
+	Removed     bool        `json:"removed"`
+}
+
+func (m LogMetadata) MarshalJSON() ([]byte, error) {
+	return json.Marshal(logMetadataJSON{
+		Address:     jsonAddress(m.Address),
+		BlockNumber: m.BlockNumber,
+		BlockHash:   m.BlockHash,
+		TxHash:      m.TxHash,
+		TxIndex:     m.TxIndex,
+		LogIndex:    m.LogIndex,
+		Removed:     m.Removed,
+	})
+}
+
+func (m *LogMetadata) UnmarshalJSON(b []byte) error {
+	var dec logMetadataJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	m.Address = common.Address(dec.Address)
+	m.BlockNumber = dec.BlockNumber
+	m.BlockHash = dec.BlockHash
+	m.TxHash = dec.TxHash
+	m.TxIndex = dec.TxIndex
+	m.LogIndex = dec.LogIndex
+	m.Removed = dec.Removed
+	return nil
+}
+
+// eventMetadataKey is the key of the log metadata in the encoding of the events returned by
+// ParseLog, which otherwise have the fields of the encoding of the event they embed.
+const eventMetadataKey = "metadata"
+
+// marshalEventJSON encodes event with the metadata of its log. The events returned by ParseLog
+// would otherwise be encoded by the MarshalJSON method of the event they embed, dropping the
+// metadata.
+func marshalEventJSON(event json.Marshaler, metadata LogMetadata) ([]byte, error) {
+	b, err := event.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	if fields[eventMetadataKey], err = json.Marshal(metadata); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// unmarshalEventJSON decodes an encoding of marshalEventJSON into event, and the position of its
+// log into raw. The topics and data of the log are not encoded, so are left unset.
+func unmarshalEventJSON(b []byte, event json.Unmarshaler, raw *types.Log) error {
+	if err := event.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	var dec map[string]json.RawMessage
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+	encoded, ok := dec[eventMetadataKey]
+	if !ok {
+		return nil
+	}
+	var metadata LogMetadata
+	if err := json.Unmarshal(encoded, &metadata); err != nil {
+		return err
+	}
+	raw.Address = metadata.Address
+	raw.BlockNumber = metadata.BlockNumber
+	raw.BlockHash = metadata.BlockHash
+	raw.TxHash = metadata.TxHash
+	raw.TxIndex = metadata.TxIndex
+	raw.Index = metadata.LogIndex
+	raw.Removed = metadata.Removed
+	return nil
+}
+
+func (e SendCrossChainMessageEvent) MarshalJSON() ([]byte, error) {
+	return marshalEventJSON(e.TeleporterMessengerSendCrossChainMessage, e.Metadata())
+}
+
+func (e *SendCrossChainMessageEvent) UnmarshalJSON(b []byte) error {
+	return unmarshalEventJSON(b, &e.TeleporterMessengerSendCrossChainMessage, &e.Raw)
+}
+
+func (e ReceiveCrossChainMessageEvent) MarshalJSON() ([]byte, error) {
+	return marshalEventJSON(e.TeleporterMessengerReceiveCrossChainMessage, e.Metadata())
+}
+
+func (e *ReceiveCrossChainMessageEvent) UnmarshalJSON(b []byte) error {
+	return unmarshalEventJSON(b, &e.TeleporterMessengerReceiveCrossChainMessage, &e.Raw)
+}
+
+func (e AddFeeAmountEvent) MarshalJSON() ([]byte, error) {
+	return marshalEventJSON(e.TeleporterMessengerAddFeeAmount, e.Metadata())
+}
+
+func (e *AddFeeAmountEvent) UnmarshalJSON(b []byte) error {
+	return unmarshalEventJSON(b, &e.TeleporterMessengerAddFeeAmount, &e.Raw)
+}
+
+func (e MessageExecutionFailedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEventJSON(e.TeleporterMessengerMessageExecutionFailed, e.Metadata())
+}
+
+func (e *MessageExecutionFailedEvent) UnmarshalJSON(b []byte) error {
+	return unmarshalEventJSON(b, &e.TeleporterMessengerMessageExecutionFailed, &e.Raw)
+}
+
+func (e MessageExecutedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEventJSON(e.TeleporterMessengerMessageExecuted, e.Metadata())
+}
+
+func (e *MessageExecutedEvent) UnmarshalJSON(b []byte) error {
+	return unmarshalEventJSON(b, &e.TeleporterMessengerMessageExecuted, &e.Raw)
+}
+
+func (e RelayerRewardsRedeemedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEventJSON(e.TeleporterMessengerRelayerRewardsRedeemed, e.Metadata())
+}
+
+func (e *RelayerRewardsRedeemedEvent) UnmarshalJSON(b []byte) error {
+	return unmarshalEventJSON(b, &e.TeleporterMessengerRelayerRewardsRedeemed, &e.Raw)
+}
+
+func (e ReceiptReceivedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEventJSON(e.TeleporterMessengerReceiptReceived, e.Metadata())
+}
+
+func (e *ReceiptReceivedEvent) UnmarshalJSON(b []byte) error {
+	return unmarshalEventJSON(b, &e.TeleporterMessengerReceiptReceived, &e.Raw)
+}
+
+func (e BlockchainIDInitializedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEventJSON(e.TeleporterMessengerBlockchainIDInitialized, e.Metadata())
+}
+
+func (e *BlockchainIDInitializedEvent) UnmarshalJSON(b []byte) error {
+	return unmarshalEventJSON(b, &e.TeleporterMessengerBlockchainIDInitialized, &e.Raw)
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeleporterMessageJSON(t *testing.T) {
+	message := createTestTeleporterMessage(big.NewInt(8))
+
+	b, err := json.Marshal(message)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"messageNonce": "8",
+		"originSenderAddress": "0x0123456789abcDEF0123456789abCDef01234567",
+		"destinationBlockchainID": "`+ids.ID{1, 2, 3, 4}.String()+`",
+		"destinationAddress": "0x0123456789abcDEF0123456789abCDef01234567",
+		"requiredGasLimit": "2",
+		"allowedRelayerAddresses": ["0x0123456789abcDEF0123456789abCDef01234567"],
+		"receipts": [
+			{
+				"receivedMessageNonce": "1",
+				"relayerRewardAddress": "0x0123456789abcDEF0123456789abCDef01234567"
+			}
+		],
+		"message": "0x01020304"
+	}`, string(b))
+
+	var decoded TeleporterMessage
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Equal(t, message, decoded)
+}
+
+func TestEventJSONRoundTrip(t *testing.T) {
+	mockBlockchainID := ids.ID{5, 6, 7, 8}
+	mockMessageID := ids.ID{9, 10, 11, 12}
+	message := createTestTeleporterMessage(big.NewInt(8))
+	feeInfo := TeleporterFeeInfo{
+		FeeTokenAddress: common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567"),
+		Amount:          big.NewInt(1),
+	}
+	relayer := common.HexToAddress("0x76543210fedcba9876543210fedcba9876543210")
+
+	tests := []interface{}{
+		&feeInfo,
+		&TeleporterMessageReceipt{
+			ReceivedMessageNonce: big.NewInt(3),
+			RelayerRewardAddress: relayer,
+		},
+		&TeleporterMessageInput{
+			DestinationBlockchainID: mockBlockchainID,
+			DestinationAddress:      relayer,
+			FeeInfo:                 feeInfo,
+			RequiredGasLimit:        big.NewInt(100_000),
+			AllowedRelayerAddresses: []common.Address{relayer},
+			Message:                 []byte{1, 2, 3},
+		},
+		&TeleporterMessengerSendCrossChainMessage{
+			MessageID:               mockMessageID,
+			DestinationBlockchainID: mockBlockchainID,
+			Message:                 message,
+			FeeInfo:                 feeInfo,
+		},
+		&TeleporterMessengerReceiveCrossChainMessage{
+			MessageID:          mockMessageID,
+			SourceBlockchainID: mockBlockchainID,
+			Deliverer:          relayer,
+			RewardRedeemer:     relayer,
+			Message:            message,
+		},
+		&TeleporterMessengerAddFeeAmount{
+			MessageID:      mockMessageID,
+			UpdatedFeeInfo: feeInfo,
+		},
+		&TeleporterMessengerMessageExecutionFailed{
+			MessageID:          mockMessageID,
+			SourceBlockchainID: mockBlockchainID,
+			Message:            message,
+		},
+		&TeleporterMessengerMessageExecuted{
+			MessageID:          mockMessageID,
+			SourceBlockchainID: mockBlockchainID,
+		},
+		&TeleporterMessengerRelayerRewardsRedeemed{
+			Redeemer: relayer,
+			Asset:    feeInfo.FeeTokenAddress,
+			Amount:   big.NewInt(2),
+		},
+		&TeleporterMessengerReceiptReceived{
+			MessageID:               mockMessageID,
+			DestinationBlockchainID: mockBlockchainID,
+			RelayerRewardAddress:    relayer,
+			FeeInfo:                 feeInfo,
+		},
+		&TeleporterMessengerBlockchainIDInitialized{
+			BlockchainID: mockBlockchainID,
+		},
+	}
+
+	for _, expected := range tests {
+		t.Run(reflect.TypeOf(expected).Elem().Name(), func(t *testing.T) {
+			b, err := json.Marshal(expected)
+			require.NoError(t, err)
+
+			decoded := reflect.New(reflect.TypeOf(expected).Elem()).Interface()
+			require.NoError(t, json.Unmarshal(b, decoded))
+			require.Equal(t, expected, decoded)
+		})
+	}
+}
+
+func TestEventIDsJSON(t *testing.T) {
+	event := TeleporterMessengerMessageExecuted{
+		MessageID:          ids.ID{9, 10, 11, 12},
+		SourceBlockchainID: ids.ID{5, 6, 7, 8},
+	}
+	b, err := json.Marshal(event)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"messageID": "`+common.Hash(event.MessageID).Hex()+`",
+		"sourceBlockchainID": "`+ids.ID(event.SourceBlockchainID).String()+`"
+	}`, string(b))
+}
+
+func TestJSONErrors(t *testing.T) {
+	var feeInfo TeleporterFeeInfo
+	err := json.Unmarshal([]byte(`{"feeTokenAddress": "0x1234", "amount": "1"}`), &feeInfo)
+	require.ErrorContains(t, err, "invalid address")
+
+	err = json.Unmarshal(
+		[]byte(`{"feeTokenAddress": "0x0123456789abcdef0123456789abcdef01234567", "amount": "0x1"}`),
+		&feeInfo,
+	)
+	require.ErrorContains(t, err, "invalid decimal integer")
+
+	// Integers are uint256, so negative and oversized values are rejected.
+	for _, amount := range []string{"-1", new(big.Int).Lsh(big.NewInt(1), 256).String()} {
+		err = json.Unmarshal(
+			[]byte(`{"feeTokenAddress": "0x0123456789abcdef0123456789abcdef01234567", "amount": "`+amount+`"}`),
+			&feeInfo,
+		)
+		require.ErrorContains(t, err, "invalid uint256")
+	}
+}
+
+func TestEventText(t *testing.T) {
+	b, err := json.Marshal([]Event{SendCrossChainMessage, BlockchainIDInitialized})
+	require.NoError(t, err)
+	require.Equal(t, `["SendCrossChainMessage","BlockchainIDInitialized"]`, string(b))
+
+	var events []Event
+	require.NoError(t, json.Unmarshal([]byte(`["receiptReceived","MessageExecuted"]`), &events))
+	require.Equal(t, []Event{ReceiptReceived, MessageExecuted}, events)
+
+	require.Error(t, json.Unmarshal([]byte(`["NotAnEvent"]`), &events))
+
+	// Unknown round trips, while values without a name are not encoded.
+	b, err = json.Marshal(Unknown)
+	require.NoError(t, err)
+	var event Event = SendCrossChainMessage
+	require.NoError(t, json.Unmarshal(b, &event))
+	require.Equal(t, Unknown, event)
+
+	_, err = json.Marshal(BlockchainIDInitialized + 1)
+	require.ErrorContains(t, err, "invalid event")
+}
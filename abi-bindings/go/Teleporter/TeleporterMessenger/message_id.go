@@ -0,0 +1,54 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// messageIDArguments is the ABI encoding of the inputs of a message ID.
+var messageIDArguments abi.Arguments
+
+func init() {
+	for _, t := range []string{"address", "bytes32", "bytes32", "uint256"} {
+		typ, err := abi.NewType(t, "", nil)
+		if err != nil {
+			panic(fmt.Sprintf("failed to create %s ABI type: %v", t, err))
+		}
+		messageIDArguments = append(messageIDArguments, abi.Argument{Type: typ})
+	}
+}
+
+// CalculateMessageID computes the ID of a message sent by the Teleporter contract at the given
+// address, matching the contract's calculateMessageID:
+// keccak256(abi.encode(teleporterAddress, sourceBlockchainID, destinationBlockchainID, nonce)).
+func CalculateMessageID(
+	teleporterAddress common.Address,
+	sourceBlockchainID ids.ID,
+	destinationBlockchainID ids.ID,
+	nonce *big.Int,
+) (ids.ID, error) {
+	b, err := messageIDArguments.Pack(teleporterAddress, sourceBlockchainID, destinationBlockchainID, nonce)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to pack message ID inputs: %w", err)
+	}
+	return ids.ID(crypto.Keccak256Hash(b)), nil
+}
+
+// CalculateMessageHash computes the hash of a message that the Teleporter contract stores when the
+// message is sent, and returns from getMessageHash until the message's receipt is received. It is
+// the keccak256 hash of the ABI encoding of the message.
+func CalculateMessageHash(message TeleporterMessage) (common.Hash, error) {
+	b, err := PackTeleporterMessage(message)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to pack teleporter message: %w", err)
+	}
+	return crypto.Keccak256Hash(b), nil
+}
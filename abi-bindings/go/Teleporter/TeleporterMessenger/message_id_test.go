@@ -0,0 +1,61 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateMessageID(t *testing.T) {
+	teleporterAddress := common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+	sourceBlockchainID := ids.ID{1, 2, 3}
+	destinationBlockchainID := ids.ID{4, 5, 6}
+	nonce := big.NewInt(42)
+
+	messageID, err := CalculateMessageID(teleporterAddress, sourceBlockchainID, destinationBlockchainID, nonce)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		common.HexToHash("0x3206f5dec98ca5e78f9d521f9c7d82d663902c4f62e9253d964f69760ea8c567"),
+		common.Hash(messageID),
+	)
+
+	// The contract encodes its own address followed by the arguments of calculateMessageID.
+	input, err := PackCalculateMessageID(sourceBlockchainID, destinationBlockchainID, nonce)
+	require.NoError(t, err)
+	expected := crypto.Keccak256(common.LeftPadBytes(teleporterAddress.Bytes(), 32), input[4:])
+	require.Equal(t, expected, messageID[:])
+
+	otherID, err := CalculateMessageID(teleporterAddress, destinationBlockchainID, sourceBlockchainID, nonce)
+	require.NoError(t, err)
+	require.NotEqual(t, messageID, otherID)
+
+	otherID, err = CalculateMessageID(common.Address{}, sourceBlockchainID, destinationBlockchainID, nonce)
+	require.NoError(t, err)
+	require.NotEqual(t, messageID, otherID)
+}
+
+func TestCalculateMessageHash(t *testing.T) {
+	message := createTestTeleporterMessage(big.NewInt(4))
+
+	messageHash, err := CalculateMessageHash(message)
+	require.NoError(t, err)
+
+	// retrySendCrossChainMessage checks the stored hash against keccak256(abi.encode(message)), where
+	// abi.encode(message) is its calldata after the function selector.
+	input, err := PackRetrySendCrossChainMessage(message)
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256Hash(input[4:]), messageHash)
+
+	message.Message = append(message.Message, 5)
+	otherHash, err := CalculateMessageHash(message)
+	require.NoError(t, err)
+	require.NotEqual(t, messageHash, otherHash)
+}
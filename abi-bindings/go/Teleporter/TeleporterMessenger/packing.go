@@ -85,6 +85,26 @@ func PackRetryMessageExecution(sourceBlockchainID ids.ID, message TeleporterMess
 	return abi.Pack("retryMessageExecution", sourceBlockchainID, message)
 }
 
+// PackAddFeeAmount packs input to form a call to the addFeeAmount function
+func PackAddFeeAmount(messageID [32]byte, feeTokenAddress common.Address, additionalFeeAmount *big.Int) ([]byte, error) {
+	abi, err := TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+
+	return abi.Pack("addFeeAmount", messageID, feeTokenAddress, additionalFeeAmount)
+}
+
+// PackRetrySendCrossChainMessage packs input to form a call to the retrySendCrossChainMessage function
+func PackRetrySendCrossChainMessage(message TeleporterMessage) ([]byte, error) {
+	abi, err := TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+
+	return abi.Pack("retrySendCrossChainMessage", message)
+}
+
 // PackReceiveCrossChainMessage packs a ReceiveCrossChainMessageInput to form a call to the receiveCrossChainMessage function
 func PackReceiveCrossChainMessage(messageIndex uint32, relayerRewardAddress common.Address) ([]byte, error) {
 	abi, err := TeleporterMessengerMetaData.GetAbi()
@@ -147,6 +167,31 @@ func PackMessageReceivedOutput(success bool) ([]byte, error) {
 	return abi.PackOutput("messageReceived", success)
 }
 
+// PackRedeemRelayerRewards packs input to form a call to the redeemRelayerRewards function
+func PackRedeemRelayerRewards(feeTokenAddress common.Address) ([]byte, error) {
+	abi, err := TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+
+	return abi.Pack("redeemRelayerRewards", feeTokenAddress)
+}
+
+// PackSendSpecifiedReceipts packs input to form a call to the sendSpecifiedReceipts function
+func PackSendSpecifiedReceipts(
+	sourceBlockchainID [32]byte,
+	messageIDs [][32]byte,
+	feeInfo TeleporterFeeInfo,
+	allowedRelayerAddresses []common.Address,
+) ([]byte, error) {
+	abi, err := TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+
+	return abi.Pack("sendSpecifiedReceipts", sourceBlockchainID, messageIDs, feeInfo, allowedRelayerAddresses)
+}
+
 // UnpackEvent unpacks the event data and topics into the provided interface
 func UnpackEvent(out interface{}, event string, topics []common.Hash, data []byte) error {
 	teleporterABI, err := TeleporterMessengerMetaData.GetAbi()
@@ -0,0 +1,180 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"bytes"
+	"fmt"
+
+	gasUtils "github.com/ava-labs/teleporter/utils/gas-utils"
+	"github.com/pkg/errors"
+)
+
+// Errors wrapped by the ValidationError returned by UnpackTeleporterMessageStrict.
+var (
+	ErrMalformedEncoding       = errors.New("malformed encoding")
+	ErrNonCanonicalEncoding    = errors.New("non-canonical encoding")
+	ErrMissingField            = errors.New("missing field")
+	ErrTooManyAllowedRelayers  = errors.New("too many allowed relayer addresses")
+	ErrTooManyReceipts         = errors.New("too many receipts")
+	ErrMessageTooLarge         = errors.New("message too large")
+	ErrRequiredGasLimitTooHigh = errors.New("receive gas limit exceeds block gas limit")
+)
+
+// ValidationError is returned by UnpackTeleporterMessageStrict when a Teleporter message is
+// rejected. Err is one of the validation errors of this package, Field is the name of the
+// offending field of the message, or empty if the error refers to the whole encoding, and Reason
+// describes the failure.
+type ValidationError struct {
+	Field  string
+	Err    error
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	msg := "invalid teleporter message"
+	if e.Field != "" {
+		msg += " " + e.Field
+	}
+	msg += ": " + e.Err.Error()
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationLimits are the limits enforced by UnpackTeleporterMessageStrict. A zero limit is not
+// enforced. The Teleporter contract only bounds the number of receipts it attaches to a message
+// itself, to MaxReceiptBatchSize, so the other limits are policies of the caller.
+type ValidationLimits struct {
+	// MaxAllowedRelayers is the maximum number of allowed relayer addresses.
+	MaxAllowedRelayers int
+	// MaxReceipts is the maximum number of receipts.
+	MaxReceipts int
+	// MaxMessageSize is the maximum size in bytes of the message payload.
+	MaxMessageSize int
+	// BlockGasLimit is the gas limit of blocks of the destination blockchain. The gas limit of the
+	// receiveCrossChainMessage transaction delivering the message, which is the required gas limit
+	// of the message plus the overhead of the delivery, must not exceed it for the message to be
+	// deliverable.
+	BlockGasLimit uint64
+	// NumSigners is the number of validators expected to sign the Warp message delivering the
+	// message, which adds to the delivery overhead checked against BlockGasLimit.
+	NumSigners int
+}
+
+// MaxReceiptBatchSize is the maximum number of receipts that the Teleporter contract attaches to
+// a message from its receipt queue. Messages sent by sendSpecifiedReceipts may hold more.
+const MaxReceiptBatchSize = 5
+
+// UnpackTeleporterMessageStrict unpacks a Teleporter message like UnpackTeleporterMessage, but
+// rejects encodings that are not exactly those produced by PackTeleporterMessage, such as encodings
+// with trailing bytes or with offsets that skip over unused bytes, and messages that exceed the
+// given limits. All errors are a *ValidationError.
+func UnpackTeleporterMessageStrict(messageBytes []byte, limits ValidationLimits) (*TeleporterMessage, error) {
+	message, err := UnpackTeleporterMessage(messageBytes)
+	if err != nil {
+		return nil, &ValidationError{Err: ErrMalformedEncoding, Reason: err.Error()}
+	}
+	if err := checkCanonicalEncoding(*message, messageBytes); err != nil {
+		return nil, err
+	}
+	if err := ValidateTeleporterMessage(*message, limits); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// ValidateTeleporterMessage checks that the fields of a Teleporter message are set and within the
+// given limits. All errors are a *ValidationError.
+func ValidateTeleporterMessage(message TeleporterMessage, limits ValidationLimits) error {
+	if message.MessageNonce == nil {
+		return &ValidationError{Field: "messageNonce", Err: ErrMissingField}
+	}
+	if message.RequiredGasLimit == nil {
+		return &ValidationError{Field: "requiredGasLimit", Err: ErrMissingField}
+	}
+	for i, receipt := range message.Receipts {
+		if receipt.ReceivedMessageNonce == nil {
+			return &ValidationError{
+				Field: fmt.Sprintf("receipts[%d].receivedMessageNonce", i),
+				Err:   ErrMissingField,
+			}
+		}
+	}
+
+	numRelayers := len(message.AllowedRelayerAddresses)
+	if limits.MaxAllowedRelayers > 0 && numRelayers > limits.MaxAllowedRelayers {
+		return &ValidationError{
+			Field:  "allowedRelayerAddresses",
+			Err:    ErrTooManyAllowedRelayers,
+			Reason: fmt.Sprintf("%d exceeds limit of %d", numRelayers, limits.MaxAllowedRelayers),
+		}
+	}
+	if limits.MaxReceipts > 0 && len(message.Receipts) > limits.MaxReceipts {
+		return &ValidationError{
+			Field:  "receipts",
+			Err:    ErrTooManyReceipts,
+			Reason: fmt.Sprintf("%d exceeds limit of %d", len(message.Receipts), limits.MaxReceipts),
+		}
+	}
+	if limits.MaxMessageSize > 0 && len(message.Message) > limits.MaxMessageSize {
+		return &ValidationError{
+			Field:  "message",
+			Err:    ErrMessageTooLarge,
+			Reason: fmt.Sprintf("%d bytes exceeds limit of %d", len(message.Message), limits.MaxMessageSize),
+		}
+	}
+	if limits.BlockGasLimit > 0 {
+		gasLimit, err := gasUtils.CalculateReceiveMessageGasLimit(limits.NumSigners, message.RequiredGasLimit)
+		if err != nil {
+			return &ValidationError{
+				Field:  "requiredGasLimit",
+				Err:    ErrRequiredGasLimitTooHigh,
+				Reason: fmt.Sprintf("required gas limit %s: %v", message.RequiredGasLimit, err),
+			}
+		}
+		if gasLimit > limits.BlockGasLimit {
+			return &ValidationError{
+				Field: "requiredGasLimit",
+				Err:   ErrRequiredGasLimitTooHigh,
+				Reason: fmt.Sprintf(
+					"receive gas limit %d for required gas limit %s exceeds %d",
+					gasLimit, message.RequiredGasLimit, limits.BlockGasLimit,
+				),
+			}
+		}
+	}
+	return nil
+}
+
+// checkCanonicalEncoding checks that messageBytes is the encoding of message produced by
+// PackTeleporterMessage.
+func checkCanonicalEncoding(message TeleporterMessage, messageBytes []byte) error {
+	canonical, err := PackTeleporterMessage(message)
+	if err != nil {
+		return &ValidationError{Err: ErrMalformedEncoding, Reason: err.Error()}
+	}
+	if bytes.Equal(canonical, messageBytes) {
+		return nil
+	}
+	if bytes.HasPrefix(messageBytes, canonical) {
+		return &ValidationError{
+			Err:    ErrNonCanonicalEncoding,
+			Reason: fmt.Sprintf("%d trailing bytes", len(messageBytes)-len(canonical)),
+		}
+	}
+	i := 0
+	for i < len(canonical) && i < len(messageBytes) && canonical[i] == messageBytes[i] {
+		i++
+	}
+	return &ValidationError{
+		Err:    ErrNonCanonicalEncoding,
+		Reason: fmt.Sprintf("differs from canonical encoding at byte %d", i),
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	gasUtils "github.com/ava-labs/teleporter/utils/gas-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackTeleporterMessageStrict(t *testing.T) {
+	message := createTestTeleporterMessage(big.NewInt(4))
+	canonical, err := PackTeleporterMessage(message)
+	require.NoError(t, err)
+
+	// Point the offset of the message to one word later, after an unused word.
+	oversizedOffset := append(common.LeftPadBytes([]byte{0x40}, 32), make([]byte, 32)...)
+	oversizedOffset = append(oversizedOffset, canonical[32:]...)
+
+	largeMessage := createTestTeleporterMessage(big.NewInt(4))
+	largeMessage.AllowedRelayerAddresses = append(largeMessage.AllowedRelayerAddresses, common.Address{})
+	largeMessage.Receipts = append(largeMessage.Receipts, largeMessage.Receipts[0])
+	largeMessage.Message = make([]byte, 33)
+	largeMessage.RequiredGasLimit = big.NewInt(8_000_000)
+	large, err := PackTeleporterMessage(largeMessage)
+	require.NoError(t, err)
+
+	limits := ValidationLimits{
+		MaxAllowedRelayers: 1,
+		MaxReceipts:        1,
+		MaxMessageSize:     32,
+		BlockGasLimit:      8_000_000,
+	}
+
+	tests := []struct {
+		name   string
+		bytes  []byte
+		limits ValidationLimits
+		err    error
+		field  string
+	}{
+		{
+			name:   "canonical",
+			bytes:  canonical,
+			limits: limits,
+		},
+		{
+			name:  "truncated",
+			bytes: canonical[:len(canonical)-32],
+			err:   ErrMalformedEncoding,
+		},
+		{
+			name:  "trailing bytes",
+			bytes: append(append([]byte{}, canonical...), 0),
+			err:   ErrNonCanonicalEncoding,
+		},
+		{
+			name:  "oversized offset",
+			bytes: oversizedOffset,
+			err:   ErrNonCanonicalEncoding,
+		},
+		{
+			name:  "no limits",
+			bytes: large,
+		},
+		{
+			name:   "too many allowed relayers",
+			bytes:  large,
+			limits: ValidationLimits{MaxAllowedRelayers: 1},
+			err:    ErrTooManyAllowedRelayers,
+			field:  "allowedRelayerAddresses",
+		},
+		{
+			name:   "too many receipts",
+			bytes:  large,
+			limits: ValidationLimits{MaxReceipts: 1},
+			err:    ErrTooManyReceipts,
+			field:  "receipts",
+		},
+		{
+			name:   "message too large",
+			bytes:  large,
+			limits: ValidationLimits{MaxMessageSize: 32},
+			err:    ErrMessageTooLarge,
+			field:  "message",
+		},
+		{
+			name:   "required gas limit too high",
+			bytes:  large,
+			limits: ValidationLimits{BlockGasLimit: 8_000_000},
+			err:    ErrRequiredGasLimitTooHigh,
+			field:  "requiredGasLimit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unpacked, err := UnpackTeleporterMessageStrict(tt.bytes, tt.limits)
+			if tt.err == nil {
+				require.NoError(t, err)
+				// The lenient unpacker decodes the same message.
+				expected, err := UnpackTeleporterMessage(tt.bytes)
+				require.NoError(t, err)
+				require.Equal(t, expected, unpacked)
+				return
+			}
+			require.ErrorIs(t, err, tt.err)
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			require.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+
+	// The non-canonical encodings are accepted by the lenient unpacker.
+	unpacked, err := UnpackTeleporterMessage(oversizedOffset)
+	require.NoError(t, err)
+	require.Equal(t, message, *unpacked)
+}
+
+func TestValidateTeleporterMessageMissingFields(t *testing.T) {
+	message := createTestTeleporterMessage(big.NewInt(4))
+	message.Receipts[0].ReceivedMessageNonce = nil
+
+	err := ValidateTeleporterMessage(message, ValidationLimits{})
+	require.ErrorIs(t, err, ErrMissingField)
+	require.EqualError(t, err, "invalid teleporter message receipts[0].receivedMessageNonce: missing field")
+}
+
+func TestValidateTeleporterMessageGasLimit(t *testing.T) {
+	const blockGasLimit = 8_000_000
+	// The delivery of a message adds a static cost and a buffer to its required gas limit.
+	overhead := gasUtils.ReceiveCrossChainMessageStaticGasCost + gasUtils.ReceiveMessageGasLimitBufferAmount
+
+	tests := []struct {
+		name             string
+		requiredGasLimit *big.Int
+		numSigners       int
+		err              error
+	}{
+		{
+			name:             "fits with overhead",
+			requiredGasLimit: new(big.Int).SetUint64(blockGasLimit - overhead),
+		},
+		{
+			name:             "exceeds with overhead",
+			requiredGasLimit: new(big.Int).SetUint64(blockGasLimit - overhead + 1),
+			err:              ErrRequiredGasLimitTooHigh,
+		},
+		{
+			name:             "just under block gas limit",
+			requiredGasLimit: big.NewInt(blockGasLimit - 1),
+			err:              ErrRequiredGasLimitTooHigh,
+		},
+		{
+			name:             "exceeds with signers",
+			requiredGasLimit: new(big.Int).SetUint64(blockGasLimit - overhead),
+			numSigners:       1,
+			err:              ErrRequiredGasLimitTooHigh,
+		},
+		{
+			name:             "exceeds uint64",
+			requiredGasLimit: new(big.Int).Lsh(big.NewInt(1), 64),
+			err:              ErrRequiredGasLimitTooHigh,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := createTestTeleporterMessage(big.NewInt(4))
+			message.RequiredGasLimit = tt.requiredGasLimit
+			err := ValidateTeleporterMessage(message, ValidationLimits{
+				BlockGasLimit: blockGasLimit,
+				NumSigners:    tt.numSigners,
+			})
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,363 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/rpc"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	gasUtils "github.com/ava-labs/teleporter/utils/gas-utils"
+	teleporterUtils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	executionReverted       = "execution reverted"
+	executionRevertedPrefix = executionReverted + ": "
+)
+
+var errNoStatusBackend = errors.New("message status requires an ethclient.Client backend")
+
+// Backend is the subset of ethclient.Client used by the Client to submit transactions and wait for
+// their receipts. It is implemented by the simulated backend of the bind package.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// RevertError is returned by the Client when a call to the Teleporter contract reverts, either
+// while estimating the gas of a transaction, in which case TxHash is the zero hash, or once the
+// transaction is included in a block. Reason is the decoded revert reason, if any.
+type RevertError struct {
+	Reason string
+	TxHash common.Hash
+}
+
+func (e *RevertError) Error() string {
+	var b strings.Builder
+	if e.TxHash != (common.Hash{}) {
+		fmt.Fprintf(&b, "transaction %s ", e.TxHash.Hex())
+	}
+	b.WriteString(executionReverted)
+	if e.Reason != "" {
+		b.WriteString(": ")
+		b.WriteString(e.Reason)
+	}
+	return b.String()
+}
+
+// Client submits transactions to a TeleporterMessenger contract, waits for them to be accepted and
+// returns the decoded Teleporter events that they emitted. Transactions are signed with the
+// transact options the client was created with, and their gas limit is estimated, so that reverts
+// are returned as a *RevertError before any transaction is submitted.
+type Client struct {
+	backend           Backend
+	teleporterAddress common.Address
+	messenger         *teleportermessenger.TeleporterMessenger
+	contract          *bind.BoundContract
+	opts              bind.TransactOpts
+}
+
+// NewClient returns a client of the TeleporterMessenger contract at the given address, which signs
+// transactions with opts. Only the From and Signer fields of opts are required. Its Context and
+// GasLimit fields are overridden for each transaction.
+func NewClient(
+	backend Backend,
+	teleporterAddress common.Address,
+	opts *bind.TransactOpts,
+) (*Client, error) {
+	messenger, err := teleportermessenger.NewTeleporterMessenger(teleporterAddress, backend)
+	if err != nil {
+		return nil, err
+	}
+	teleporterABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		backend:           backend,
+		teleporterAddress: teleporterAddress,
+		messenger:         messenger,
+		contract:          bind.NewBoundContract(teleporterAddress, *teleporterABI, backend, backend, backend),
+		opts:              *opts,
+	}, nil
+}
+
+// NewKeyedClient returns a client of the TeleporterMessenger contract at the given address, which
+// signs transactions with the given private key for the chain ID reported by the client.
+func NewKeyedClient(
+	ctx context.Context,
+	client ethclient.Client,
+	teleporterAddress common.Address,
+	key *ecdsa.PrivateKey,
+) (*Client, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(client, teleporterAddress, opts)
+}
+
+// Messenger returns the binding of the TeleporterMessenger contract used by the client.
+func (c *Client) Messenger() *teleportermessenger.TeleporterMessenger {
+	return c.messenger
+}
+
+// SendMessage sends a cross chain message, and returns its ID. If the message has a non-zero fee,
+// the sender must have already approved the Teleporter contract to spend the fee amount.
+func (c *Client) SendMessage(
+	ctx context.Context,
+	input teleportermessenger.TeleporterMessageInput,
+) (ids.ID, *types.Receipt, error) {
+	data, err := teleportermessenger.PackSendCrossChainMessage(input)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	receipt, err := c.transact(ctx, data, 0)
+	if err != nil {
+		return ids.Empty, receipt, err
+	}
+	event, err := EventFromLogs(receipt.Logs, c.teleporterAddress, c.messenger.ParseSendCrossChainMessage)
+	if err != nil {
+		return ids.Empty, receipt, err
+	}
+	return event.MessageID, receipt, nil
+}
+
+// AddFee adds the amount of the fee token to the relayer fee of a message whose receipt has not
+// been received, and returns the AddFeeAmount event with the updated fee. The sender must have
+// already approved the Teleporter contract to spend the amount.
+func (c *Client) AddFee(
+	ctx context.Context,
+	messageID ids.ID,
+	feeTokenAddress common.Address,
+	amount *big.Int,
+) (*teleportermessenger.TeleporterMessengerAddFeeAmount, *types.Receipt, error) {
+	data, err := teleportermessenger.PackAddFeeAmount(messageID, feeTokenAddress, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipt, err := c.transact(ctx, data, 0)
+	if err != nil {
+		return nil, receipt, err
+	}
+	event, err := EventFromLogs(receipt.Logs, c.teleporterAddress, c.messenger.ParseAddFeeAmount)
+	if err != nil {
+		return nil, receipt, err
+	}
+	return event, receipt, nil
+}
+
+// RetryExecution retries the execution of a message received from the source blockchain whose
+// execution failed, and returns the MessageExecuted event. The transaction's gas limit is at least
+// that of a delivery of the message, so that the receiver is given the message's required gas limit.
+func (c *Client) RetryExecution(
+	ctx context.Context,
+	sourceBlockchainID ids.ID,
+	message teleportermessenger.TeleporterMessage,
+) (*teleportermessenger.TeleporterMessengerMessageExecuted, *types.Receipt, error) {
+	gasLimit, err := gasUtils.CalculateReceiveMessageGasLimit(0, message.RequiredGasLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := teleportermessenger.PackRetryMessageExecution(sourceBlockchainID, message)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipt, err := c.transact(ctx, data, gasLimit)
+	if err != nil {
+		return nil, receipt, err
+	}
+	event, err := EventFromLogs(receipt.Logs, c.teleporterAddress, c.messenger.ParseMessageExecuted)
+	if err != nil {
+		return nil, receipt, err
+	}
+	return event, receipt, nil
+}
+
+// SendSpecifiedReceipts sends the receipts of the given messages received from the source
+// blockchain back to it in a new message, and returns the ID of the new message.
+func (c *Client) SendSpecifiedReceipts(
+	ctx context.Context,
+	sourceBlockchainID ids.ID,
+	messageIDs []ids.ID,
+	feeInfo teleportermessenger.TeleporterFeeInfo,
+	allowedRelayerAddresses []common.Address,
+) (ids.ID, *types.Receipt, error) {
+	messageIDBytes := make([][32]byte, len(messageIDs))
+	for i, messageID := range messageIDs {
+		messageIDBytes[i] = messageID
+	}
+	data, err := teleportermessenger.PackSendSpecifiedReceipts(
+		sourceBlockchainID, messageIDBytes, feeInfo, allowedRelayerAddresses,
+	)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	receipt, err := c.transact(ctx, data, 0)
+	if err != nil {
+		return ids.Empty, receipt, err
+	}
+	event, err := EventFromLogs(receipt.Logs, c.teleporterAddress, c.messenger.ParseSendCrossChainMessage)
+	if err != nil {
+		return ids.Empty, receipt, err
+	}
+	return event.MessageID, receipt, nil
+}
+
+// RedeemRewards redeems the sender's relayer rewards of the fee token, and returns the
+// RelayerRewardsRedeemed event with the redeemed amount.
+func (c *Client) RedeemRewards(
+	ctx context.Context,
+	feeTokenAddress common.Address,
+) (*teleportermessenger.TeleporterMessengerRelayerRewardsRedeemed, *types.Receipt, error) {
+	data, err := teleportermessenger.PackRedeemRelayerRewards(feeTokenAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipt, err := c.transact(ctx, data, 0)
+	if err != nil {
+		return nil, receipt, err
+	}
+	event, err := EventFromLogs(receipt.Logs, c.teleporterAddress, c.messenger.ParseRelayerRewardsRedeemed)
+	if err != nil {
+		return nil, receipt, err
+	}
+	return event, receipt, nil
+}
+
+// MessageStatus reports the status of a message sent from the client's chain to the destination
+// chain, whose Teleporter contract is at destinationTeleporterAddress, searching the last
+// DefaultLookBackBlocks blocks of each chain for its logs. The client's backend must be an
+// ethclient.Client.
+func (c *Client) MessageStatus(
+	ctx context.Context,
+	destination ethclient.Client,
+	destinationTeleporterAddress common.Address,
+	messageID ids.ID,
+) (*teleporterUtils.MessageStatus, error) {
+	source, ok := c.backend.(ethclient.Client)
+	if !ok {
+		return nil, errNoStatusBackend
+	}
+	return teleporterUtils.GetMessageStatus(
+		ctx,
+		source,
+		destination,
+		c.teleporterAddress,
+		destinationTeleporterAddress,
+		messageID,
+		teleporterUtils.DefaultLookBackBlocks,
+	)
+}
+
+// transact estimates the gas of a call to the Teleporter contract with the given data, then
+// submits it with a gas limit of at least minGasLimit and waits for it to be accepted. The receipt
+// is returned along with a *RevertError if the transaction reverted once included.
+func (c *Client) transact(ctx context.Context, data []byte, minGasLimit uint64) (*types.Receipt, error) {
+	msg := interfaces.CallMsg{
+		From: c.opts.From,
+		To:   &c.teleporterAddress,
+		Data: data,
+	}
+	gasLimit, err := c.backend.EstimateGas(ctx, msg)
+	if err != nil {
+		if reason, ok := RevertReason(err); ok {
+			return nil, &RevertError{Reason: reason}
+		}
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	if gasLimit < minGasLimit {
+		gasLimit = minGasLimit
+	}
+
+	opts := c.opts
+	opts.Context = ctx
+	opts.GasLimit = gasLimit
+	tx, err := c.contract.RawTransact(&opts, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	receipt, err := bind.WaitMined(ctx, c.backend, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		// Replay the call on the state before the transaction's block to recover the revert reason.
+		// Other transactions earlier in the block may mean the replay does not revert.
+		revertErr := &RevertError{TxHash: tx.Hash()}
+		msg.Gas = tx.Gas()
+		_, err := c.backend.CallContract(ctx, msg, new(big.Int).Sub(receipt.BlockNumber, common.Big1))
+		if reason, ok := RevertReason(err); ok {
+			revertErr.Reason = reason
+		}
+		return receipt, revertErr
+	}
+	return receipt, nil
+}
+
+// RevertReason returns the revert reason of an error returned by eth_call or eth_estimateGas, and
+// whether the call reverted. Only errors carrying revert data or the "execution reverted" message
+// are reverts. Other node errors, such as insufficient funds or a nonce that is too low, and errors
+// not returned by the node, such as connection errors, are not.
+func RevertReason(err error) (string, bool) {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return "", false
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if s, ok := dataErr.ErrorData().(string); ok {
+			if data, decodeErr := hexutil.Decode(s); decodeErr == nil {
+				if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+					return strings.TrimSpace(reason), true
+				}
+			}
+		}
+	}
+	message := rpcErr.Error()
+	if message == executionReverted {
+		return "", true
+	}
+	if !strings.HasPrefix(message, executionRevertedPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(message, executionRevertedPrefix), true
+}
+
+// EventFromLogs returns the first log emitted by the given contract address that is successfully
+// parsed by parser.
+func EventFromLogs[T any](
+	logs []*types.Log,
+	address common.Address,
+	parser func(log types.Log) (T, error),
+) (T, error) {
+	for _, log := range logs {
+		if log.Address != address {
+			continue
+		}
+		event, err := parser(*log)
+		if err == nil {
+			return event, nil
+		}
+	}
+	return *new(T), fmt.Errorf("failed to find %T event in receipt logs", *new(T))
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// testCallError is an error returned by a node for a reverted call, with optional revert data.
+type testCallError struct {
+	message string
+	data    interface{}
+}
+
+func (e *testCallError) Error() string          { return e.message }
+func (e *testCallError) ErrorCode() int         { return 3 }
+func (e *testCallError) ErrorData() interface{} { return e.data }
+
+// packTestRevert ABI encodes reason as the data of a revert with Error(string).
+func packTestRevert(reason string) []byte {
+	data := append([]byte{}, crypto.Keccak256([]byte("Error(string)"))[:4]...)
+	data = append(data, common.LeftPadBytes([]byte{0x20}, 32)...)
+	data = append(data, common.LeftPadBytes([]byte{byte(len(reason))}, 32)...)
+	return append(data, common.RightPadBytes([]byte(reason), (len(reason)+31)/32*32)...)
+}
+
+func TestRevertReason(t *testing.T) {
+	var tests = []struct {
+		name   string
+		err    error
+		reason string
+		ok     bool
+	}{
+		{
+			name: "revert data",
+			err: &testCallError{
+				message: "execution reverted: TeleporterMessenger: message not found",
+				data:    hexutil.Encode(packTestRevert("TeleporterMessenger: message not found")),
+			},
+			reason: "TeleporterMessenger: message not found",
+			ok:     true,
+		},
+		{
+			name:   "no revert data",
+			err:    &testCallError{message: "execution reverted: TeleporterMessenger: zero additional fee amount"},
+			reason: "TeleporterMessenger: zero additional fee amount",
+			ok:     true,
+		},
+		{
+			name:   "no revert data from another contract",
+			err:    &testCallError{message: "execution reverted: ReentrancyGuard: reentrant call"},
+			reason: "ReentrancyGuard: reentrant call",
+			ok:     true,
+		},
+		{
+			name:   "no revert reason",
+			err:    &testCallError{message: "execution reverted"},
+			reason: "",
+			ok:     true,
+		},
+		{
+			name: "wrapped",
+			err: fmt.Errorf("call failed: %w", &testCallError{
+				message: "execution reverted: TeleporterMessenger: message not found",
+			}),
+			reason: "TeleporterMessenger: message not found",
+			ok:     true,
+		},
+		{
+			name: "insufficient funds",
+			err:  &testCallError{message: "insufficient funds for gas * price + value"},
+		},
+		{
+			name: "nonce too low",
+			err:  fmt.Errorf("call failed: %w", &testCallError{message: "nonce too low"}),
+		},
+		{
+			name: "connection error",
+			err:  errors.New("connection refused"),
+		},
+		{
+			name: "no error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := RevertReason(tt.err)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestRevertError(t *testing.T) {
+	txHash := common.HexToHash("0x01")
+	require.Equal(t, "execution reverted", (&RevertError{}).Error())
+	require.Equal(t,
+		"execution reverted: TeleporterMessenger: message not found",
+		(&RevertError{Reason: "TeleporterMessenger: message not found"}).Error(),
+	)
+	require.Equal(t,
+		fmt.Sprintf("transaction %s execution reverted: TeleporterMessenger: message not found", txHash.Hex()),
+		(&RevertError{Reason: "TeleporterMessenger: message not found", TxHash: txHash}).Error(),
+	)
+}
@@ -0,0 +1,286 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind/backends"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/types"
+	exampleerc20 "github.com/ava-labs/teleporter/abi-bindings/go/Mocks/ExampleERC20"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	warpMessengerAddress = common.HexToAddress("0x0200000000000000000000000000000000000005")
+	testBlockchainID     = ids.ID{1, 2, 3}
+)
+
+// committingBackend is a simulated backend that mines a block for each transaction it is sent, so
+// that bind.WaitMined returns without a separate goroutine committing blocks.
+type committingBackend struct {
+	*backends.SimulatedBackend
+}
+
+func (b *committingBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := b.SimulatedBackend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	b.Commit(true)
+	return nil
+}
+
+// mockWarpMessengerCode returns a 32 byte word to any call. The Teleporter contract only reads the
+// blockchain ID returned by getBlockchainID, and ignores the message ID returned by sendWarpMessage,
+// so both return testBlockchainID.
+func mockWarpMessengerCode() []byte {
+	code := []byte{0x7f} // PUSH32 testBlockchainID
+	code = append(code, testBlockchainID[:]...)
+	return append(code,
+		0x60, 0x00, // PUSH1 0
+		0x52,       // MSTORE
+		0x60, 0x20, // PUSH1 32
+		0x60, 0x00, // PUSH1 0
+		0xf3, // RETURN
+	)
+}
+
+type testEnv struct {
+	backend           *committingBackend
+	client            *Client
+	opts              *bind.TransactOpts
+	teleporterAddress common.Address
+	feeTokenAddress   common.Address
+	feeToken          *exampleerc20.ExampleERC20
+}
+
+// newTestEnv deploys the Teleporter contract and a fee token to a simulated backend with a mock
+// Warp precompile, and returns a client of the Teleporter contract.
+func newTestEnv(t *testing.T) *testEnv {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{
+		opts.From:            {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+		warpMessengerAddress: {Balance: common.Big0, Code: mockWarpMessengerCode()},
+	}, 10_000_000)
+	t.Cleanup(func() { sim.Close() })
+	backend := &committingBackend{sim}
+
+	teleporterAddress, _, _, err := teleportermessenger.DeployTeleporterMessenger(opts, backend)
+	require.NoError(t, err)
+	feeTokenAddress, _, feeToken, err := exampleerc20.DeployExampleERC20(opts, backend)
+	require.NoError(t, err)
+
+	client, err := NewClient(backend, teleporterAddress, opts)
+	require.NoError(t, err)
+	return &testEnv{
+		backend:           backend,
+		client:            client,
+		opts:              opts,
+		teleporterAddress: teleporterAddress,
+		feeTokenAddress:   feeTokenAddress,
+		feeToken:          feeToken,
+	}
+}
+
+func (e *testEnv) approve(t *testing.T, amount *big.Int) {
+	_, err := e.feeToken.Approve(e.opts, e.teleporterAddress, amount)
+	require.NoError(t, err)
+}
+
+func (e *testEnv) nonce(t *testing.T) uint64 {
+	nonce, err := e.backend.AcceptedNonceAt(context.Background(), e.opts.From)
+	require.NoError(t, err)
+	return nonce
+}
+
+func testMessageInput(feeInfo teleportermessenger.TeleporterFeeInfo) teleportermessenger.TeleporterMessageInput {
+	return teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: ids.ID{4, 5, 6},
+		DestinationAddress:      common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567"),
+		FeeInfo:                 feeInfo,
+		RequiredGasLimit:        big.NewInt(100_000),
+		AllowedRelayerAddresses: []common.Address{},
+		Message:                 []byte{1, 2, 3, 4},
+	}
+}
+
+func TestClientSendMessage(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	input := testMessageInput(teleportermessenger.TeleporterFeeInfo{Amount: big.NewInt(0)})
+	messageID, receipt, err := env.client.SendMessage(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+
+	expectedID, err := teleportermessenger.CalculateMessageID(
+		env.teleporterAddress, testBlockchainID, input.DestinationBlockchainID, big.NewInt(1),
+	)
+	require.NoError(t, err)
+	require.Equal(t, expectedID, messageID)
+
+	// The stored message hash matches the sent message.
+	event, err := EventFromLogs(receipt.Logs, env.teleporterAddress, env.client.Messenger().ParseSendCrossChainMessage)
+	require.NoError(t, err)
+	require.Equal(t, input.Message, event.Message.Message)
+	expectedHash, err := teleportermessenger.CalculateMessageHash(event.Message)
+	require.NoError(t, err)
+	messageHash, err := env.client.Messenger().GetMessageHash(&bind.CallOpts{}, messageID)
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, common.Hash(messageHash))
+
+	// The second message has the next nonce.
+	messageID, _, err = env.client.SendMessage(ctx, input)
+	require.NoError(t, err)
+	expectedID, err = teleportermessenger.CalculateMessageID(
+		env.teleporterAddress, testBlockchainID, input.DestinationBlockchainID, big.NewInt(2),
+	)
+	require.NoError(t, err)
+	require.Equal(t, expectedID, messageID)
+}
+
+func TestClientSendMessageRevert(t *testing.T) {
+	env := newTestEnv(t)
+	nonce := env.nonce(t)
+
+	// A fee without a fee token reverts while estimating gas, before a transaction is sent.
+	input := testMessageInput(teleportermessenger.TeleporterFeeInfo{Amount: big.NewInt(1)})
+	_, receipt, err := env.client.SendMessage(context.Background(), input)
+	var revertErr *RevertError
+	require.ErrorAs(t, err, &revertErr)
+	require.Equal(t, "TeleporterMessenger: zero fee asset contract address", revertErr.Reason)
+	require.Equal(t, common.Hash{}, revertErr.TxHash)
+	require.Nil(t, receipt)
+	require.Equal(t, nonce, env.nonce(t))
+}
+
+func TestClientAddFee(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.approve(t, big.NewInt(30))
+
+	input := testMessageInput(teleportermessenger.TeleporterFeeInfo{
+		FeeTokenAddress: env.feeTokenAddress,
+		Amount:          big.NewInt(10),
+	})
+	messageID, _, err := env.client.SendMessage(ctx, input)
+	require.NoError(t, err)
+
+	event, receipt, err := env.client.AddFee(ctx, messageID, env.feeTokenAddress, big.NewInt(20))
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.Equal(t, messageID, ids.ID(event.MessageID))
+	require.Equal(t, env.feeTokenAddress, event.UpdatedFeeInfo.FeeTokenAddress)
+	require.Equal(t, big.NewInt(30), event.UpdatedFeeInfo.Amount)
+
+	// Fees must be paid in the message's fee token.
+	_, _, err = env.client.AddFee(ctx, messageID, env.teleporterAddress, big.NewInt(1))
+	var revertErr *RevertError
+	require.ErrorAs(t, err, &revertErr)
+	require.Equal(t, "TeleporterMessenger: invalid fee asset contract address", revertErr.Reason)
+
+	_, _, err = env.client.AddFee(ctx, messageID, env.feeTokenAddress, big.NewInt(0))
+	require.ErrorAs(t, err, &revertErr)
+	require.Equal(t, "TeleporterMessenger: zero additional fee amount", revertErr.Reason)
+}
+
+func TestClientRetryExecution(t *testing.T) {
+	env := newTestEnv(t)
+	nonce := env.nonce(t)
+
+	message := teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		OriginSenderAddress:     env.opts.From,
+		DestinationBlockchainID: testBlockchainID,
+		DestinationAddress:      env.feeTokenAddress,
+		RequiredGasLimit:        big.NewInt(100_000),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 []byte{1, 2, 3, 4},
+	}
+	// The message was never received, so its execution can't have failed.
+	_, receipt, err := env.client.RetryExecution(context.Background(), ids.ID{7, 8, 9}, message)
+	var revertErr *RevertError
+	require.ErrorAs(t, err, &revertErr)
+	require.Equal(t, "TeleporterMessenger: message not found", revertErr.Reason)
+	require.Nil(t, receipt)
+	require.Equal(t, nonce, env.nonce(t))
+}
+
+func TestClientSendSpecifiedReceipts(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	sourceBlockchainID := ids.ID{7, 8, 9}
+	feeInfo := teleportermessenger.TeleporterFeeInfo{Amount: big.NewInt(0)}
+
+	// No message was received from the source blockchain.
+	_, _, err := env.client.SendSpecifiedReceipts(ctx, sourceBlockchainID, []ids.ID{{1}}, feeInfo, nil)
+	var revertErr *RevertError
+	require.ErrorAs(t, err, &revertErr)
+	require.Equal(t, "TeleporterMessenger: receipt not found", revertErr.Reason)
+
+	// Sending no receipts sends an empty message back to the source blockchain.
+	messageID, receipt, err := env.client.SendSpecifiedReceipts(ctx, sourceBlockchainID, nil, feeInfo, nil)
+	require.NoError(t, err)
+	event, err := EventFromLogs(receipt.Logs, env.teleporterAddress, env.client.Messenger().ParseSendCrossChainMessage)
+	require.NoError(t, err)
+	require.Equal(t, messageID, ids.ID(event.MessageID))
+	require.Equal(t, sourceBlockchainID, ids.ID(event.DestinationBlockchainID))
+	require.Empty(t, event.Message.Receipts)
+	require.Empty(t, event.Message.Message)
+}
+
+func TestClientRedeemRewards(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, receipt, err := env.client.RedeemRewards(context.Background(), env.feeTokenAddress)
+	var revertErr *RevertError
+	require.ErrorAs(t, err, &revertErr)
+	require.Equal(t, "TeleporterMessenger: no reward to redeem", revertErr.Reason)
+	require.Nil(t, receipt)
+}
+
+func TestClientMessageStatusBackend(t *testing.T) {
+	env := newTestEnv(t)
+
+	// The simulated backend is not an ethclient.Client.
+	_, err := env.client.MessageStatus(context.Background(), nil, env.client.teleporterAddress, ids.Empty)
+	require.ErrorIs(t, err, errNoStatusBackend)
+}
+
+func TestClientTransactMinGasLimit(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	data, err := teleportermessenger.PackSendCrossChainMessage(
+		testMessageInput(teleportermessenger.TeleporterFeeInfo{Amount: big.NewInt(0)}),
+	)
+	require.NoError(t, err)
+
+	// The estimated gas limit is used if it is above the minimum gas limit.
+	receipt, err := env.client.transact(ctx, data, 1)
+	require.NoError(t, err)
+	tx, _, err := env.backend.TransactionByHash(ctx, receipt.TxHash)
+	require.NoError(t, err)
+	require.Greater(t, tx.Gas(), uint64(1))
+
+	// Otherwise the gas limit is raised to the minimum gas limit.
+	minGasLimit := tx.Gas() + 500_000
+	receipt, err = env.client.transact(ctx, data, minGasLimit)
+	require.NoError(t, err)
+	tx, _, err = env.backend.TransactionByHash(ctx, receipt.TxHash)
+	require.NoError(t, err)
+	require.Equal(t, minGasLimit, tx.Gas())
+}
@@ -0,0 +1,66 @@
+package teleporterregistry
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+var protocolRegistryEntryPayloadArgs abi.Arguments
+
+func init() {
+	// Create an ABI binding for the payload of the off-chain Warp message received by addProtocolVersion,
+	// decoded in TeleporterRegistry.sol as (ProtocolRegistryEntry, address). abigen does not support ABI
+	// bindings for standalone structs, so we must manually keep this up-to-date with the contract.
+	entryType, err := abi.NewType("tuple", "struct ProtocolRegistryEntry", []abi.ArgumentMarshaling{
+		{Name: "version", Type: "uint256"},
+		{Name: "protocolAddress", Type: "address"},
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to create ProtocolRegistryEntry ABI type: %v", err))
+	}
+	addressType, err := abi.NewType("address", "", nil)
+	if err != nil {
+		panic(fmt.Sprintf("failed to create address ABI type: %v", err))
+	}
+	protocolRegistryEntryPayloadArgs = abi.Arguments{
+		{Name: "entry", Type: entryType},
+		{Name: "destinationAddress", Type: addressType},
+	}
+}
+
+// PackAddProtocolVersion packs input to form a call to the addProtocolVersion function
+func PackAddProtocolVersion(messageIndex uint32) ([]byte, error) {
+	abi, err := TeleporterRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+
+	return abi.Pack("addProtocolVersion", messageIndex)
+}
+
+// PackProtocolRegistryEntryPayload packs the payload of an off-chain Warp message registering the entry
+// with the registry at the destination address
+func PackProtocolRegistryEntryPayload(entry ProtocolRegistryEntry, destinationAddress common.Address) ([]byte, error) {
+	return protocolRegistryEntryPayloadArgs.Pack(entry, destinationAddress)
+}
+
+// UnpackProtocolRegistryEntryPayload unpacks the payload of an off-chain Warp message registering a
+// protocol version, returning the entry and the address of the registry it is sent to
+func UnpackProtocolRegistryEntryPayload(payload []byte) (*ProtocolRegistryEntry, common.Address, error) {
+	unpacked, err := protocolRegistryEntryPayloadArgs.Unpack(payload)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	type payloadArgs struct {
+		Entry              ProtocolRegistryEntry `json:"entry"`
+		DestinationAddress common.Address        `json:"destinationAddress"`
+	}
+	var args payloadArgs
+	if err := protocolRegistryEntryPayloadArgs.Copy(&args, unpacked); err != nil {
+		return nil, common.Address{}, err
+	}
+	return &args.Entry, args.DestinationAddress, nil
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleporterregistry
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackUnpackProtocolRegistryEntryPayload(t *testing.T) {
+	entry := ProtocolRegistryEntry{
+		Version:         big.NewInt(2),
+		ProtocolAddress: common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567"),
+	}
+	destinationAddress := common.HexToAddress("0x89abcdef0123456789abcdef0123456789abcdef")
+
+	b, err := PackProtocolRegistryEntryPayload(entry, destinationAddress)
+	require.NoError(t, err)
+	// The static tuple and address are each encoded in place.
+	require.Len(t, b, 3*32)
+
+	unpackedEntry, unpackedDestination, err := UnpackProtocolRegistryEntryPayload(b)
+	require.NoError(t, err)
+	require.Equal(t, entry.Version, unpackedEntry.Version)
+	require.Equal(t, entry.ProtocolAddress, unpackedEntry.ProtocolAddress)
+	require.Equal(t, destinationAddress, unpackedDestination)
+}
+
+func TestUnpackProtocolRegistryEntryPayloadInvalid(t *testing.T) {
+	_, _, err := UnpackProtocolRegistryEntryPayload([]byte{1, 2, 3})
+	require.Error(t, err)
+}
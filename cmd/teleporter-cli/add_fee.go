@@ -0,0 +1,248 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	exampleerc20 "github.com/ava-labs/teleporter/abi-bindings/go/Mocks/ExampleERC20"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterClient "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	addFeeMessageIDArg    string
+	addFeeAmountArg       string
+	addFeeTokenAddressArg string
+
+	errMessageReceipted      = errors.New("message not found, its receipt has already been received or it was never sent")
+	errZeroAdditionalFee     = errors.New("additional fee amount must be non-zero")
+	errMessageHasNoFeeToken  = errors.New("message was sent without a fee token, so no fee can be added to it")
+	errFeeTokenAddressChange = errors.New("fee token address does not match the message's fee token")
+)
+
+var addFeeCmd = &cobra.Command{
+	Use:   "add-fee --rpc RPC_URL --teleporter-address CONTRACT_ADDRESS --message-id MESSAGE_ID --amount AMOUNT",
+	Short: "Adds to the relayer fee of a sent message",
+	Long: `Given the ID of a message sent from the connected chain and an amount, this
+command adds the amount to the message's relayer fee. The message's current fee
+is read with getFeeInfo, and the sender's balance and allowance of the fee token
+are checked. If the Teleporter contract's allowance is insufficient, an approve
+transaction is submitted for the amount before addFeeAmount is submitted. The
+updated fee is read from the AddFeeAmount log of the transaction. The command
+refuses to run if the message's receipt has already been received, since its fee
+has then already been paid out.`,
+	Args: cobra.NoArgs,
+	RunE: addFeeRunE,
+}
+
+// addFeeOutput is the output schema of the add-fee command. Amounts are decimal strings in the
+// fee token's smallest unit.
+type addFeeOutput struct {
+	TxHash          string `json:"txHash" yaml:"txHash"`
+	ApproveTxHash   string `json:"approveTxHash,omitempty" yaml:"approveTxHash,omitempty"`
+	MessageID       string `json:"messageID" yaml:"messageID"`
+	FeeTokenAddress string `json:"feeTokenAddress" yaml:"feeTokenAddress"`
+	PreviousAmount  string `json:"previousAmount" yaml:"previousAmount"`
+	UpdatedAmount   string `json:"updatedAmount" yaml:"updatedAmount"`
+}
+
+func addFeeRunE(cmd *cobra.Command, args []string) error {
+	messageID, err := parseID(addFeeMessageIDArg)
+	if err != nil {
+		return err
+	}
+	amount, err := parseAdditionalFeeAmount(addFeeAmountArg)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	signer, err := loadSigner(ctx)
+	if err != nil {
+		return err
+	}
+	sender := signer.Address()
+
+	opts := &bind.CallOpts{Context: ctx}
+	messenger, err := teleportermessenger.NewTeleporterMessenger(teleporterAddress, client)
+	if err != nil {
+		return err
+	}
+
+	// The message's hash and fee are cleared once its receipt is received.
+	messageHash, err := messenger.GetMessageHash(opts, messageID)
+	if err != nil {
+		return err
+	}
+	if messageHash == [32]byte{} {
+		return errMessageReceipted
+	}
+	feeTokenAddress, previousAmount, err := messenger.GetFeeInfo(opts, messageID)
+	if err != nil {
+		return err
+	}
+	if err := checkFeeTokenAddress(feeTokenAddress, addFeeTokenAddressArg); err != nil {
+		return err
+	}
+
+	token, err := exampleerc20.NewExampleERC20Caller(feeTokenAddress, client)
+	if err != nil {
+		return err
+	}
+	balance, err := token.BalanceOf(opts, sender)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(amount) < 0 {
+		return fmt.Errorf("insufficient fee token balance: %s has %s, need %s", sender.Hex(), balance, amount)
+	}
+
+	out := addFeeOutput{
+		MessageID:       common.Hash(messageID).Hex(),
+		FeeTokenAddress: feeTokenAddress.Hex(),
+		PreviousAmount:  previousAmount.String(),
+	}
+	allowance, err := token.Allowance(opts, sender, teleporterAddress)
+	if err != nil {
+		return err
+	}
+	if dryRunArg {
+		return addFeeDryRun(ctx, cmd, signer, messageID, feeTokenAddress, amount, allowance)
+	}
+	if allowance.Cmp(amount) < 0 {
+		data, err := packApprove(amount)
+		if err != nil {
+			return err
+		}
+		receipt, err := createAndSendTransaction(ctx, client, signer, feeTokenAddress, data, 0)
+		if err != nil {
+			return fmt.Errorf("failed to approve fee token: %w", err)
+		}
+		logger.Info("Approved fee token",
+			zap.String("txHash", receipt.TxHash.Hex()),
+			zap.Stringer("amount", amount))
+		out.ApproveTxHash = receipt.TxHash.Hex()
+	}
+
+	data, err := teleportermessenger.PackAddFeeAmount(messageID, feeTokenAddress, amount)
+	if err != nil {
+		return err
+	}
+	receipt, err := createAndSendTransaction(ctx, client, signer, teleporterAddress, data, 0)
+	if err != nil {
+		return err
+	}
+	event, err := teleporterClient.EventFromLogs(receipt.Logs, teleporterAddress, messenger.ParseAddFeeAmount)
+	if err != nil {
+		return err
+	}
+	out.TxHash = receipt.TxHash.Hex()
+	out.UpdatedAmount = event.UpdatedFeeInfo.Amount.String()
+
+	if err := printOutput(cmd, out); err != nil {
+		return err
+	}
+	cmd.Println("Add fee command ran successfully")
+	return nil
+}
+
+// parseAdditionalFeeAmount parses the amount to add to a message's fee, which must be non-zero.
+func parseAdditionalFeeAmount(amountArg string) (*big.Int, error) {
+	amount, err := parseUint256(amountArg)
+	if err != nil {
+		return nil, err
+	}
+	if amount.Sign() <= 0 {
+		return nil, errZeroAdditionalFee
+	}
+	return amount, nil
+}
+
+// checkFeeTokenAddress checks that a fee can be added to a message with the given fee token, and
+// that the fee token address argument, if set, matches it.
+func checkFeeTokenAddress(feeTokenAddress common.Address, tokenAddressArg string) error {
+	if feeTokenAddress == (common.Address{}) {
+		return errMessageHasNoFeeToken
+	}
+	if tokenAddressArg == "" {
+		return nil
+	}
+	tokenAddress, err := parseAddress(tokenAddressArg)
+	if err != nil {
+		return err
+	}
+	if tokenAddress != feeTokenAddress {
+		return errFeeTokenAddressChange
+	}
+	return nil
+}
+
+// packApprove packs an approval of the given amount of the fee token to the Teleporter contract.
+func packApprove(amount *big.Int) ([]byte, error) {
+	tokenABI, err := exampleerc20.ExampleERC20MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return tokenABI.Pack("approve", teleporterAddress, amount)
+}
+
+// addFeeDryRun simulates the transactions of the add-fee command. If the fee token must be approved
+// first, only the approval is simulated and signed, since adding the fee would revert until the
+// approval is accepted.
+func addFeeDryRun(
+	ctx context.Context,
+	cmd *cobra.Command,
+	signer txSigner,
+	messageID [32]byte,
+	feeTokenAddress common.Address,
+	amount *big.Int,
+	allowance *big.Int,
+) error {
+	data, err := teleportermessenger.PackAddFeeAmount(messageID, feeTokenAddress, amount)
+	if err != nil {
+		return err
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return simulateAndPrintTransaction(ctx, cmd, client, signer, teleporterAddress, data, 0)
+	}
+
+	approveData, err := packApprove(amount)
+	if err != nil {
+		return err
+	}
+	approveTx, err := simulateTransaction(ctx, client, signer, feeTokenAddress, approveData, 0)
+	if err != nil {
+		return err
+	}
+	addFeeTx := &dryRunTxOutput{
+		From: signer.Address().Hex(),
+		To:   teleporterAddress.Hex(),
+		Data: hexString(data),
+		Note: "not simulated or signed, since it depends on the fee token approval",
+	}
+	return printDryRun(cmd, approveTx, addFeeTx)
+}
+
+func init() {
+	rootCmd.AddCommand(addFeeCmd)
+	addClientFlags(addFeeCmd)
+	addKeyFlags(addFeeCmd)
+	addFeeCmd.Flags().StringVar(&addFeeMessageIDArg, "message-id", "", "ID of the message, CB58 or hex encoded")
+	addFeeCmd.Flags().StringVar(&addFeeAmountArg, "amount", "",
+		"Additional fee amount, in the fee token's smallest unit")
+	addFeeCmd.Flags().StringVar(&addFeeTokenAddressArg, "fee-token-address", "",
+		"ERC20 contract address of the fee. Defaults to the message's fee token, and must match it if set")
+
+	for _, flag := range []string{"message-id", "amount"} {
+		err := addFeeCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddFeeCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"add-fee"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"add-fee", "--help"},
+			err:  nil,
+			out:  "Given the ID of a message sent from the connected chain and an amount, this",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestParseAdditionalFeeAmount(t *testing.T) {
+	var tests = []struct {
+		name string
+		arg  string
+		out  *big.Int
+		err  error
+	}{
+		{
+			name: "non-zero",
+			arg:  "100",
+			out:  big.NewInt(100),
+		},
+		{
+			name: "zero",
+			arg:  "0",
+			err:  errZeroAdditionalFee,
+		},
+		{
+			name: "negative",
+			arg:  "-1",
+			err:  errors.New("invalid uint256 -1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := parseAdditionalFeeAmount(tt.arg)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 0, tt.out.Cmp(amount))
+		})
+	}
+}
+
+func TestCheckFeeTokenAddress(t *testing.T) {
+	feeTokenAddress := common.HexToAddress("0x5DB9A7629912EBF95876228C24A848de0bfB43A9")
+
+	var tests = []struct {
+		name            string
+		feeTokenAddress common.Address
+		arg             string
+		err             error
+	}{
+		{
+			name:            "defaults to the message's fee token",
+			feeTokenAddress: feeTokenAddress,
+		},
+		{
+			name:            "matching fee token",
+			feeTokenAddress: feeTokenAddress,
+			arg:             feeTokenAddress.Hex(),
+		},
+		{
+			name:            "matching fee token in lower case",
+			feeTokenAddress: feeTokenAddress,
+			arg:             "0x5db9a7629912ebf95876228c24a848de0bfb43a9",
+		},
+		{
+			name:            "other fee token",
+			feeTokenAddress: feeTokenAddress,
+			arg:             common.HexToAddress("0x1").Hex(),
+			err:             errFeeTokenAddressChange,
+		},
+		{
+			name:            "invalid fee token address",
+			feeTokenAddress: feeTokenAddress,
+			arg:             "0x1234",
+			err:             errors.New("invalid address 0x1234"),
+		},
+		{
+			name: "message without a fee token",
+			arg:  feeTokenAddress.Hex(),
+			err:  errMessageHasNoFeeToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkFeeTokenAddress(tt.feeTokenAddress, tt.arg)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/x/warp"
+	teleporterUtils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fromBlockArg uint64
+	toBlockArg   uint64
+	chunkSizeArg uint64
+)
+
+var blockRangeCmd = &cobra.Command{
+	Use:   "block-range --rpc RPC_URL --teleporter-address CONTRACT_ADDRESS --from-block BLOCK [--to-block BLOCK]",
+	Short: "Parses all Teleporter logs in a range of blocks",
+	Long: `Given a range of blocks this command queries the logs emitted by the Teleporter
+contract, and the Warp messages sent by the Teleporter contract, in chunks of
+blocks to respect RPC range limits. The decoded logs are grouped by transaction,
+followed by a summary of the number of logs per event type and per destination
+blockchain.`,
+	Args: cobra.NoArgs,
+	RunE: blockRangeRunE,
+}
+
+// blockRangeOutput is the output schema of the block-range command.
+type blockRangeOutput struct {
+	FromBlock    uint64            `json:"fromBlock" yaml:"fromBlock"`
+	ToBlock      uint64            `json:"toBlock" yaml:"toBlock"`
+	Transactions []transactionLogs `json:"transactions" yaml:"transactions"`
+	Summary      blockRangeSummary `json:"summary" yaml:"summary"`
+}
+
+// transactionLogs are the decoded logs of a single transaction.
+type transactionLogs struct {
+	TxHash      string      `json:"txHash" yaml:"txHash"`
+	BlockNumber uint64      `json:"blockNumber" yaml:"blockNumber"`
+	Logs        []logOutput `json:"logs" yaml:"logs"`
+}
+
+// blockRangeSummary counts the decoded logs by event type, and by event type for each
+// destination blockchain ID, keyed by its CB58 encoding.
+type blockRangeSummary struct {
+	Total                  int                       `json:"total" yaml:"total"`
+	Events                 map[string]int            `json:"events" yaml:"events"`
+	DestinationBlockchains map[string]map[string]int `json:"destinationBlockchains" yaml:"destinationBlockchains"`
+}
+
+func blockRangeRunE(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	toBlock, err := resolveToBlock(ctx, fromBlockArg, toBlockArg)
+	if err != nil {
+		return err
+	}
+
+	logs, err := filterBlockRangeLogs(ctx, fromBlockArg, toBlock)
+	if err != nil {
+		return err
+	}
+
+	out, err := newBlockRangeOutput(fromBlockArg, toBlock, logs)
+	if err != nil {
+		return err
+	}
+	if err := printOutput(cmd, out); err != nil {
+		return err
+	}
+	cmd.Println("Block range command ran successfully")
+	return nil
+}
+
+// resolveToBlock returns the last block of the range, defaulting to the latest block if toBlock is zero.
+func resolveToBlock(ctx context.Context, fromBlock uint64, toBlock uint64) (uint64, error) {
+	if toBlock == 0 {
+		latest, err := client.BlockNumber(ctx)
+		if err != nil {
+			return 0, err
+		}
+		toBlock = latest
+	}
+	if fromBlock > toBlock {
+		return 0, fmt.Errorf("from block %d is after to block %d", fromBlock, toBlock)
+	}
+	return toBlock, nil
+}
+
+// newBlockRangeOutput decodes the given logs, sorted in the order they were emitted, and groups them
+// by transaction. Logs of other contracts, and Warp logs not sent by the Teleporter contract, are skipped.
+func newBlockRangeOutput(fromBlock uint64, toBlock uint64, logs []types.Log) (*blockRangeOutput, error) {
+	out := &blockRangeOutput{
+		FromBlock:    fromBlock,
+		ToBlock:      toBlock,
+		Transactions: []transactionLogs{},
+		Summary: blockRangeSummary{
+			Events:                 make(map[string]int),
+			DestinationBlockchains: make(map[string]map[string]int),
+		},
+	}
+	for i := range logs {
+		log := &logs[i]
+		decoded, err := decodeLog(log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode log %d of transaction %s: %w", log.Index, log.TxHash.Hex(), err)
+		}
+		if decoded == nil {
+			continue
+		}
+
+		// Logs are sorted, so the logs of a transaction are contiguous.
+		if n := len(out.Transactions); n == 0 || out.Transactions[n-1].TxHash != log.TxHash.Hex() {
+			out.Transactions = append(out.Transactions, transactionLogs{
+				TxHash:      log.TxHash.Hex(),
+				BlockNumber: log.BlockNumber,
+			})
+		}
+		tx := &out.Transactions[len(out.Transactions)-1]
+		tx.Logs = append(tx.Logs, decoded.Output)
+
+		out.Summary.add(decoded)
+	}
+	return out, nil
+}
+
+func (s *blockRangeSummary) add(decoded *decodedLog) {
+	event := decoded.Output.Event
+	s.Total++
+	s.Events[event]++
+	if decoded.DestinationBlockchainID == ids.Empty {
+		return
+	}
+	destination := decoded.DestinationBlockchainID.String()
+	if s.DestinationBlockchains[destination] == nil {
+		s.DestinationBlockchains[destination] = make(map[string]int)
+	}
+	s.DestinationBlockchains[destination][event]++
+}
+
+// filterBlockRangeLogs returns the Teleporter logs and the Warp logs of messages sent by the Teleporter
+// contract between fromBlock and toBlock inclusive, in the order they were emitted.
+func filterBlockRangeLogs(ctx context.Context, fromBlock uint64, toBlock uint64) ([]types.Log, error) {
+	teleporterLogs, err := teleporterUtils.FilterLogs(ctx, client, interfaces.FilterQuery{
+		Addresses: []common.Address{teleporterAddress},
+	}, fromBlock, toBlock, chunkSizeArg)
+	if err != nil {
+		return nil, err
+	}
+
+	warpLogs, err := teleporterUtils.FilterLogs(ctx, client, interfaces.FilterQuery{
+		Addresses: []common.Address{warp.ContractAddress},
+		Topics: [][]common.Hash{
+			{warp.WarpABI.Events[sendWarpMessageEventName].ID},
+			{common.BytesToHash(teleporterAddress.Bytes())},
+		},
+	}, fromBlock, toBlock, chunkSizeArg)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := append(teleporterLogs, warpLogs...)
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+	return logs, nil
+}
+
+func init() {
+	rootCmd.AddCommand(blockRangeCmd)
+	addClientFlags(blockRangeCmd)
+	blockRangeCmd.Flags().Uint64Var(&fromBlockArg, "from-block", 0, "First block of the range")
+	blockRangeCmd.Flags().Uint64Var(&toBlockArg, "to-block", 0, "Last block of the range, defaults to the latest block")
+	blockRangeCmd.Flags().Uint64Var(&chunkSizeArg, "chunk-size", teleporterUtils.DefaultFilterLogsChunkSize,
+		"Maximum number of blocks queried per eth_getLogs request")
+
+	err := blockRangeCmd.MarkFlagRequired("from-block")
+	cobra.CheckErr(err)
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/x/warp"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockRangeCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"block-range"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"block-range", "--help"},
+			err:  nil,
+			out:  "Given a range of blocks this command queries the logs emitted by the Teleporter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestResolveToBlock(t *testing.T) {
+	previousClient := client
+	t.Cleanup(func() {
+		client = previousClient
+	})
+	client = &fakeLogsClient{latest: 100}
+
+	var tests = []struct {
+		name      string
+		fromBlock uint64
+		toBlock   uint64
+		out       uint64
+		err       string
+	}{
+		{
+			name:      "to block",
+			fromBlock: 10,
+			toBlock:   20,
+			out:       20,
+		},
+		{
+			name:      "single block",
+			fromBlock: 20,
+			toBlock:   20,
+			out:       20,
+		},
+		{
+			name:      "defaults to latest block",
+			fromBlock: 10,
+			out:       100,
+		},
+		{
+			name:      "from block after to block",
+			fromBlock: 21,
+			toBlock:   20,
+			err:       "from block 21 is after to block 20",
+		},
+		{
+			name:      "from block after latest block",
+			fromBlock: 101,
+			err:       "from block 101 is after to block 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toBlock, err := resolveToBlock(context.Background(), tt.fromBlock, tt.toBlock)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.out, toBlock)
+		})
+	}
+}
+
+func TestBlockRangeLogs(t *testing.T) {
+	setTestGlobals(t, common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"))
+	previousClient, previousChunkSize := client, chunkSizeArg
+	t.Cleanup(func() {
+		client, chunkSizeArg = previousClient, previousChunkSize
+	})
+	chunkSizeArg = 1
+
+	txA, txB := common.Hash{0xa}, common.Hash{0xb}
+	messageExecutedLog := func(address common.Address, txHash common.Hash, blockNumber uint64, index uint) types.Log {
+		return types.Log{
+			Address:     address,
+			Topics:      []common.Hash{teleporterABI.Events["MessageExecuted"].ID, {1}, {2}},
+			TxHash:      txHash,
+			BlockNumber: blockNumber,
+			Index:       index,
+		}
+	}
+
+	destinationBlockchainID := ids.ID{1, 2, 3}
+	messageBytes, err := teleportermessenger.PackTeleporterMessage(teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		DestinationBlockchainID: destinationBlockchainID,
+		RequiredGasLimit:        big.NewInt(100_000),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 []byte{1, 2},
+	})
+	require.NoError(t, err)
+	warpLog := func(sourceAddress common.Address, txHash common.Hash, blockNumber uint64, index uint) types.Log {
+		unsignedMsg := newTestSignedWarpMessage(t, sourceAddress, messageBytes).UnsignedMessage
+		topics, data, err := warp.PackSendWarpMessageEvent(
+			sourceAddress, common.Hash(unsignedMsg.ID()), unsignedMsg.Bytes(),
+		)
+		require.NoError(t, err)
+		return types.Log{
+			Address:     warp.ContractAddress,
+			Topics:      topics,
+			Data:        data,
+			TxHash:      txHash,
+			BlockNumber: blockNumber,
+			Index:       index,
+		}
+	}
+
+	otherAddress := common.HexToAddress("0x1")
+	client = &fakeLogsClient{
+		latest: 3,
+		pastLogs: []types.Log{
+			messageExecutedLog(teleporterAddress, txA, 1, 0),
+			messageExecutedLog(teleporterAddress, txB, 3, 0),
+			warpLog(teleporterAddress, txA, 1, 1),
+			// Warp messages sent by other contracts, and logs of other contracts, are not queried.
+			warpLog(otherAddress, txA, 1, 2),
+			messageExecutedLog(otherAddress, common.Hash{0xc}, 2, 0),
+		},
+	}
+
+	// The logs of all chunks are merged in the order they were emitted.
+	logs, err := filterBlockRangeLogs(context.Background(), 0, 3)
+	require.NoError(t, err)
+	require.Equal(t, []types.Log{
+		messageExecutedLog(teleporterAddress, txA, 1, 0),
+		warpLog(teleporterAddress, txA, 1, 1),
+		messageExecutedLog(teleporterAddress, txB, 3, 0),
+	}, logs)
+
+	out, err := newBlockRangeOutput(0, 3, logs)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), out.FromBlock)
+	require.Equal(t, uint64(3), out.ToBlock)
+	require.Len(t, out.Transactions, 2)
+	require.Equal(t, txA.Hex(), out.Transactions[0].TxHash)
+	require.Equal(t, uint64(1), out.Transactions[0].BlockNumber)
+	require.Len(t, out.Transactions[0].Logs, 2)
+	require.Equal(t, "MessageExecuted", out.Transactions[0].Logs[0].Event)
+	require.Equal(t, sendWarpMessageEventName, out.Transactions[0].Logs[1].Event)
+	// Blockchain IDs of Warp logs are CB58 encoded, as in the Teleporter logs.
+	require.Equal(t, ids.ID{4, 5, 6}.String(), out.Transactions[0].Logs[1].Fields["sourceChainID"])
+	require.Equal(t, txB.Hex(), out.Transactions[1].TxHash)
+	require.Equal(t, uint64(3), out.Transactions[1].BlockNumber)
+	require.Len(t, out.Transactions[1].Logs, 1)
+	require.Equal(t, blockRangeSummary{
+		Total:  3,
+		Events: map[string]int{"MessageExecuted": 2, sendWarpMessageEventName: 1},
+		DestinationBlockchains: map[string]map[string]int{
+			destinationBlockchainID.String(): {sendWarpMessageEventName: 1},
+		},
+	}, out.Summary)
+
+	// An empty range has no transactions.
+	out, err = newBlockRangeOutput(0, 3, nil)
+	require.NoError(t, err)
+	require.Empty(t, out.Transactions)
+	require.Zero(t, out.Summary.Total)
+
+	// A Teleporter log that can't be decoded fails the command.
+	malformed := messageExecutedLog(teleporterAddress, txA, 1, 0)
+	malformed.Topics = malformed.Topics[:1]
+	_, err = newBlockRangeOutput(0, 3, []types.Log{malformed})
+	require.ErrorContains(t, err, "failed to decode log 0 of transaction "+txA.Hex())
+}
+
+func TestBlockRangeSummary(t *testing.T) {
+	summary := blockRangeSummary{
+		Events:                 make(map[string]int),
+		DestinationBlockchains: make(map[string]map[string]int),
+	}
+	destinationA, destinationB := ids.ID{1}, ids.ID{2}
+	for _, decoded := range []*decodedLog{
+		{Output: logOutput{Event: "SendCrossChainMessage"}, DestinationBlockchainID: destinationA},
+		{Output: logOutput{Event: "SendCrossChainMessage"}, DestinationBlockchainID: destinationA},
+		{Output: logOutput{Event: "SendCrossChainMessage"}, DestinationBlockchainID: destinationB},
+		{Output: logOutput{Event: "AddFeeAmount"}, DestinationBlockchainID: destinationB},
+		// Logs without a destination blockchain ID are only counted by event type.
+		{Output: logOutput{Event: "RelayerRewardsRedeemed"}},
+	} {
+		summary.add(decoded)
+	}
+
+	require.Equal(t, blockRangeSummary{
+		Total: 5,
+		Events: map[string]int{
+			"SendCrossChainMessage":  3,
+			"AddFeeAmount":           1,
+			"RelayerRewardsRedeemed": 1,
+		},
+		DestinationBlockchains: map[string]map[string]int{
+			destinationA.String(): {"SendCrossChainMessage": 2},
+			destinationB.String(): {"SendCrossChainMessage": 1, "AddFeeAmount": 1},
+		},
+	}, summary)
+}
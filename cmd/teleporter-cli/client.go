@@ -0,0 +1,69 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rpcEndpoint       string
+	teleporterAddress common.Address
+	client            ethclient.Client
+)
+
+// addClientFlags registers the --rpc and --teleporter-address flags on the given command,
+// and connects to the RPC endpoint before the command is run.
+func addClientFlags(cmd *cobra.Command) {
+	addContractClientFlags(cmd, "teleporter-address", "t", "Teleporter contract address", &teleporterAddress)
+}
+
+// addContractClientFlags registers the --rpc flag and a required contract address flag with the
+// given name on the given command, along with the --chain flag that defaults them from the config
+// file. Before the command is run, the address is parsed into dst and the client connects to the
+// RPC endpoint.
+func addContractClientFlags(cmd *cobra.Command, name string, shorthand string, usage string, dst *common.Address) {
+	cmd.PersistentFlags().StringVar(&rpcEndpoint, "rpc", "", "RPC endpoint to connect to the node")
+	cmd.PersistentFlags().StringVar(&chainArg, "chain", "", "Name of a chain in the config file to connect to")
+	address := cmd.PersistentFlags().StringP(name, shorthand, "", usage)
+	err := cmd.MarkPersistentFlagRequired("rpc")
+	cobra.CheckErr(err)
+	err = cmd.MarkPersistentFlagRequired(name)
+	cobra.CheckErr(err)
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return clientPreRunE(cmd, args, address, dst)
+	}
+}
+
+func clientPreRunE(cmd *cobra.Command, args []string, address *string, dst *common.Address) error {
+	// Run the persistent pre-run function of the root command if it exists.
+	if err := callPersistentPreRunE(cmd, args); err != nil {
+		return err
+	}
+	if chainArg != "" {
+		chain, err := selectChain(chainArg)
+		if err != nil {
+			return err
+		}
+		websocket := cmd.Annotations[chainURLAnnotation] == chainURLWebsocket
+		if err := setChainFlags(cmd, chain.clientFlagValues(websocket)); err != nil {
+			return err
+		}
+	}
+	// Persistent pre-runs are executed before cobra checks for required flags,
+	// so check them here before attempting to connect to the RPC endpoint.
+	if err := cmd.ValidateRequiredFlags(); err != nil {
+		return err
+	}
+	*dst = common.HexToAddress(*address)
+	c, err := ethclient.Dial(rpcEndpoint)
+	if err != nil {
+		return err
+	}
+
+	client = c
+	return err
+}
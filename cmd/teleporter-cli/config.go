@@ -0,0 +1,406 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultConfigFileName = ".teleporter-cli.yaml"
+
+	// chainURLAnnotation is set to chainURLWebsocket on commands that connect to a chain's
+	// websocket endpoint rather than its RPC endpoint when the chain is selected with --chain.
+	chainURLAnnotation = "teleporter-cli/chain-url"
+	chainURLWebsocket  = "ws"
+
+	// The environment variables read by tests/testnet.NewTestNetwork.
+	envTeleporterContractAddress       = "teleporter_contract_address"
+	envTeleporterRegistryAddressSuffix = "_teleporter_registry_address"
+	envSubnetIDSuffix                  = "_subnet_id"
+	envBlockchainIDSuffix              = "_chain_id"
+	envBlockchainIDAltSuffix           = "_blockchain_id"
+	envRPCURLSuffix                    = "_rpc_url"
+	envWSURLSuffix                     = "_ws_url"
+	envUserPrivateKey                  = "user_private_key"
+)
+
+// testNetworkChains maps the environment variable prefixes of the test network to the chain names
+// they are imported as.
+var testNetworkChains = []struct {
+	prefix string
+	name   string
+}{
+	{prefix: "subnet_a", name: "subnet-a"},
+	{prefix: "subnet_b", name: "subnet-b"},
+	{prefix: "subnet_c", name: "subnet-c"},
+	{prefix: "c_chain", name: "c-chain"},
+}
+
+var (
+	configFileArg    string
+	chainArg         string
+	importEnvFileArg string
+
+	errNoChainsImported = errors.New("no chains found in the environment, expected variables such as subnet_a_rpc_url")
+)
+
+// cliConfig is the schema of the config file. The signing key of the config applies to every chain
+// that does not set its own.
+type cliConfig struct {
+	PrivateKey string                  `yaml:"privateKey,omitempty"`
+	KeyFile    string                  `yaml:"keyFile,omitempty"`
+	Keystore   string                  `yaml:"keystore,omitempty"`
+	Chains     map[string]*chainConfig `yaml:"chains"`
+}
+
+// chainConfig describes a named chain in the config file.
+type chainConfig struct {
+	BlockchainID      string `yaml:"blockchainID,omitempty"`
+	SubnetID          string `yaml:"subnetID,omitempty"`
+	RPCURL            string `yaml:"rpcURL,omitempty"`
+	WSURL             string `yaml:"wsURL,omitempty"`
+	TeleporterAddress string `yaml:"teleporterAddress,omitempty"`
+	RegistryAddress   string `yaml:"registryAddress,omitempty"`
+	PrivateKey        string `yaml:"privateKey,omitempty"`
+	KeyFile           string `yaml:"keyFile,omitempty"`
+	Keystore          string `yaml:"keystore,omitempty"`
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manages the named chains of the config file",
+	Long: `The config subcommands manage the named chains of the config file, which
+defaults to ~/.teleporter-cli.yaml and can be set with --config. Commands that
+connect to a chain accept --chain NAME in place of --rpc and the contract
+address flags, and sign transactions with the chain's key if no key flag is
+set. Flags that are set explicitly take precedence over the chain's values.`,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the chains of the config file",
+	Long: `Lists the chains of the config file with their blockchain ID, subnet ID,
+endpoints and contract addresses. Signing keys are not shown.`,
+	Args: cobra.NoArgs,
+	RunE: configListRunE,
+}
+
+var configImportEnvCmd = &cobra.Command{
+	Use:   "import-env [--env-file FILE]",
+	Short: "Imports the chains of a test network environment",
+	Long: `Imports the chains described by the environment variables read by the testnet
+end-to-end tests, e.g. subnet_a_rpc_url, subnet_a_chain_id and
+teleporter_contract_address, into the config file. The variables are read from
+--env-file if set, and from the environment otherwise. The subnet_a, subnet_b,
+subnet_c and c_chain prefixes are imported as the subnet-a, subnet-b, subnet-c
+and c-chain chains, and user_private_key as the default signing key. Existing
+chains with the same names are replaced.`,
+	Args: cobra.NoArgs,
+	RunE: configImportEnvRunE,
+}
+
+// chainListOutput is the output schema of a chain in the config list command.
+type chainListOutput struct {
+	Name              string `json:"name" yaml:"name"`
+	BlockchainID      string `json:"blockchainID" yaml:"blockchainID"`
+	SubnetID          string `json:"subnetID" yaml:"subnetID"`
+	RPCURL            string `json:"rpcURL" yaml:"rpcURL"`
+	WSURL             string `json:"wsURL" yaml:"wsURL"`
+	TeleporterAddress string `json:"teleporterAddress" yaml:"teleporterAddress"`
+	RegistryAddress   string `json:"registryAddress" yaml:"registryAddress"`
+}
+
+// configListOutput is the output schema of the config list and config import-env commands.
+type configListOutput struct {
+	ConfigFile string            `json:"configFile" yaml:"configFile"`
+	Chains     []chainListOutput `json:"chains" yaml:"chains"`
+}
+
+func configListRunE(cmd *cobra.Command, args []string) error {
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	config, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	if err := printOutput(cmd, newConfigListOutput(path, config, nil)); err != nil {
+		return err
+	}
+	cmd.Println("Config list command ran successfully")
+	return nil
+}
+
+func configImportEnvRunE(cmd *cobra.Command, args []string) error {
+	env := environMap()
+	if importEnvFileArg != "" {
+		fileEnv, err := readEnvFile(importEnvFileArg)
+		if err != nil {
+			return err
+		}
+		env = fileEnv
+	}
+
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	config, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+	names, err := importEnvConfig(env, config)
+	if err != nil {
+		return err
+	}
+	if err := writeConfig(path, config); err != nil {
+		return err
+	}
+
+	if err := printOutput(cmd, newConfigListOutput(path, config, names)); err != nil {
+		return err
+	}
+	cmd.Println("Config import-env command ran successfully")
+	return nil
+}
+
+// newConfigListOutput returns the output of the named chains of the config, or of all of its chains
+// in name order if names is nil.
+func newConfigListOutput(path string, config *cliConfig, names []string) configListOutput {
+	if names == nil {
+		for name := range config.Chains {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	out := configListOutput{
+		ConfigFile: path,
+		Chains:     make([]chainListOutput, 0, len(names)),
+	}
+	for _, name := range names {
+		chain := config.Chains[name]
+		out.Chains = append(out.Chains, chainListOutput{
+			Name:              name,
+			BlockchainID:      chain.BlockchainID,
+			SubnetID:          chain.SubnetID,
+			RPCURL:            chain.RPCURL,
+			WSURL:             chain.WSURL,
+			TeleporterAddress: chain.TeleporterAddress,
+			RegistryAddress:   chain.RegistryAddress,
+		})
+	}
+	return out
+}
+
+// configFilePath returns the path of the config file from the --config flag, defaulting to
+// ~/.teleporter-cli.yaml.
+func configFilePath() (string, error) {
+	if configFileArg != "" {
+		return configFileArg, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory for the config file: %w", err)
+	}
+	return filepath.Join(home, defaultConfigFileName), nil
+}
+
+// loadConfig reads the config file at the given path. A missing file is an empty config.
+func loadConfig(path string) (*cliConfig, error) {
+	config := &cliConfig{}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		config.Chains = make(map[string]*chainConfig)
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.Unmarshal(b, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if config.Chains == nil {
+		config.Chains = make(map[string]*chainConfig)
+	}
+	return config, nil
+}
+
+// writeConfig writes the config file to the given path. The file is only readable by its owner,
+// since it may contain signing keys.
+func writeConfig(path string, config *cliConfig) error {
+	b, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// selectChain returns the named chain of the config file, and selects its signing key, or the
+// config's default key, to sign transactions when no key flag is set.
+func selectChain(name string) (*chainConfig, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return nil, err
+	}
+	config, err := loadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	chain, ok := config.Chains[name]
+	if !ok {
+		return nil, fmt.Errorf("chain %q not found in config file %s", name, path)
+	}
+
+	configKey = keySource{privateKey: config.PrivateKey, keyFile: config.KeyFile, keystore: config.Keystore}
+	chainKey := keySource{privateKey: chain.PrivateKey, keyFile: chain.KeyFile, keystore: chain.Keystore}
+	if !chainKey.isEmpty() {
+		configKey = chainKey
+	}
+	return chain, nil
+}
+
+// clientFlagValues returns the values of the client flags of a command for the chain.
+func (c *chainConfig) clientFlagValues(websocket bool) map[string]string {
+	rpc := c.RPCURL
+	if websocket {
+		rpc = c.WSURL
+	}
+	return map[string]string{
+		"rpc":                rpc,
+		"teleporter-address": c.TeleporterAddress,
+		"registry-address":   c.RegistryAddress,
+	}
+}
+
+// setChainFlags sets the flags of the command to the given values, keyed by flag name. Flags that
+// the command does not have, that were set explicitly, or whose value is empty are left unchanged.
+func setChainFlags(cmd *cobra.Command, values map[string]string) error {
+	for name, value := range values {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed || value == "" {
+			continue
+		}
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("invalid --%s value from config: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// importEnvConfig adds the chains described by the test network environment variables to the
+// config, replacing chains with the same names. Returns the names of the imported chains.
+func importEnvConfig(env map[string]string, config *cliConfig) ([]string, error) {
+	teleporterAddress := env[envTeleporterContractAddress]
+	if teleporterAddress != "" && !common.IsHexAddress(teleporterAddress) {
+		return nil, fmt.Errorf("invalid %s: %s", envTeleporterContractAddress, teleporterAddress)
+	}
+
+	var names []string
+	for _, testChain := range testNetworkChains {
+		rpcURL := env[testChain.prefix+envRPCURLSuffix]
+		if rpcURL == "" {
+			continue
+		}
+		// NewTestNetwork reads the _chain_id suffix, while .env.testnet uses _blockchain_id.
+		blockchainID := env[testChain.prefix+envBlockchainIDSuffix]
+		if blockchainID == "" {
+			blockchainID = env[testChain.prefix+envBlockchainIDAltSuffix]
+		}
+		subnetID := env[testChain.prefix+envSubnetIDSuffix]
+		for _, id := range []string{blockchainID, subnetID} {
+			if id == "" {
+				continue
+			}
+			if _, err := ids.FromString(id); err != nil {
+				return nil, fmt.Errorf("invalid ID for %s: %w", testChain.prefix, err)
+			}
+		}
+		registryAddress := env[testChain.prefix+envTeleporterRegistryAddressSuffix]
+		if registryAddress != "" && !common.IsHexAddress(registryAddress) {
+			return nil, fmt.Errorf("invalid registry address for %s: %s", testChain.prefix, registryAddress)
+		}
+
+		config.Chains[testChain.name] = &chainConfig{
+			BlockchainID:      blockchainID,
+			SubnetID:          subnetID,
+			RPCURL:            rpcURL,
+			WSURL:             env[testChain.prefix+envWSURLSuffix],
+			TeleporterAddress: teleporterAddress,
+			RegistryAddress:   registryAddress,
+		}
+		names = append(names, testChain.name)
+	}
+	if len(names) == 0 {
+		return nil, errNoChainsImported
+	}
+	if key := env[envUserPrivateKey]; key != "" {
+		config.PrivateKey = key
+	}
+	return names, nil
+}
+
+// readEnvFile reads the KEY=VALUE lines of a .env file, skipping blank lines and comments.
+func readEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open env file: %w", err)
+	}
+	defer f.Close()
+
+	env := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid env file line: %s", line)
+		}
+		env[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+	return env, nil
+}
+
+// environMap returns the environment of the process as a map.
+func environMap() map[string]string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			env[key] = value
+		}
+	}
+	return env
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configFileArg, "config", "",
+		"Path to the config file of named chains, defaults to ~/"+defaultConfigFileName)
+
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configListCmd, configImportEnvCmd)
+	configImportEnvCmd.Flags().StringVar(&importEnvFileArg, "env-file", "",
+		"Path to a .env file to read the variables from instead of the environment")
+}
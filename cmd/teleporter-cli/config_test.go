@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "help",
+			args: []string{"config", "import-env", "--help"},
+			err:  nil,
+			out:  "Imports the chains described by the environment variables read by the testnet",
+		},
+		{
+			name: "unexpected args",
+			args: []string{"config", "list", "subnet-a"},
+			err:  fmt.Errorf("unknown command"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestImportEnvConfig(t *testing.T) {
+	env := map[string]string{
+		"subnet_a_rpc_url":                     "https://subnet-a.example/rpc",
+		"subnet_a_ws_url":                      "wss://subnet-a.example/ws",
+		"subnet_a_subnet_id":                   "2PsShLjrFFwR51DMcAh8pyuwzLn1Ym3zRhuXLTmLCR1STk2mL6",
+		"subnet_a_chain_id":                    "2nFUad4Nw4pCgEF6MwYgGuKrzKbHJzM8wF29jeVUL41RWHgNRa",
+		"subnet_a_teleporter_registry_address": "0x0123456789abcdef0123456789abcdef01234567",
+		"subnet_b_rpc_url":                     "https://subnet-b.example/rpc",
+		"subnet_b_blockchain_id":               "2e3RJ3ub9Pceh8fJ3HX3gZ6nSXJLvBJ9WoXLcU4nwdpZ8X2RLq",
+		"c_chain_rpc_url":                      "",
+		"teleporter_contract_address":          "0x50A46AA7b2eCBe2B1AbB7df865B9A87f5eed8635",
+		"user_private_key":                     "0x56289e99c94b6912bfc12adc093c9b51124f0dc54ac7a766b2bc5ccf558d8027",
+	}
+	config := &cliConfig{Chains: map[string]*chainConfig{
+		"subnet-a": {RPCURL: "https://old.example/rpc"},
+		"local":    {RPCURL: "http://127.0.0.1:9650/ext/bc/C/rpc"},
+	}}
+
+	names, err := importEnvConfig(env, config)
+	require.NoError(t, err)
+	require.Equal(t, []string{"subnet-a", "subnet-b"}, names)
+	require.Equal(t, &chainConfig{
+		BlockchainID:      "2nFUad4Nw4pCgEF6MwYgGuKrzKbHJzM8wF29jeVUL41RWHgNRa",
+		SubnetID:          "2PsShLjrFFwR51DMcAh8pyuwzLn1Ym3zRhuXLTmLCR1STk2mL6",
+		RPCURL:            "https://subnet-a.example/rpc",
+		WSURL:             "wss://subnet-a.example/ws",
+		TeleporterAddress: "0x50A46AA7b2eCBe2B1AbB7df865B9A87f5eed8635",
+		RegistryAddress:   "0x0123456789abcdef0123456789abcdef01234567",
+	}, config.Chains["subnet-a"])
+	require.Equal(t, "2e3RJ3ub9Pceh8fJ3HX3gZ6nSXJLvBJ9WoXLcU4nwdpZ8X2RLq", config.Chains["subnet-b"].BlockchainID)
+	require.Contains(t, config.Chains, "local")
+	require.NotContains(t, config.Chains, "c-chain")
+	require.Equal(t, env["user_private_key"], config.PrivateKey)
+
+	_, err = importEnvConfig(map[string]string{}, config)
+	require.ErrorIs(t, err, errNoChainsImported)
+
+	_, err = importEnvConfig(map[string]string{
+		"subnet_a_rpc_url":  "https://subnet-a.example/rpc",
+		"subnet_a_chain_id": "invalid",
+	}, config)
+	require.Error(t, err)
+}
+
+func TestReadEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	contents := `# subnet A
+subnet_a_rpc_url=https://subnet-a.example/rpc
+export teleporter_contract_address="0x50A46AA7b2eCBe2B1AbB7df865B9A87f5eed8635"
+
+c_chain_rpc_url=
+`
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+
+	env, err := readEnvFile(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"subnet_a_rpc_url":            "https://subnet-a.example/rpc",
+		"teleporter_contract_address": "0x50A46AA7b2eCBe2B1AbB7df865B9A87f5eed8635",
+		"c_chain_rpc_url":             "",
+	}, env)
+}
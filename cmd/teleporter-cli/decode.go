@@ -0,0 +1,141 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/x/warp"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+const sendWarpMessageEventName = "SendWarpMessage"
+
+// decodedLog is a decoded Teleporter or Warp log, along with the identifying fields of the Teleporter
+// message it refers to. Fields that are not included in the event are left empty.
+type decodedLog struct {
+	Output                  logOutput
+	MessageID               ids.ID
+	SourceBlockchainID      ids.ID
+	DestinationBlockchainID ids.ID
+	OriginSenderAddress     common.Address
+}
+
+// setLocalBlockchainID sets the blockchain ID that the event implies is the blockchain the log was
+// emitted on, e.g. the source blockchain of a SendCrossChainMessage event.
+func (d *decodedLog) setLocalBlockchainID(blockchainID ids.ID) {
+	event, err := teleportermessenger.ToEvent(d.Output.Event)
+	if err != nil {
+		return
+	}
+	switch event {
+	case teleportermessenger.SendCrossChainMessage,
+		teleportermessenger.AddFeeAmount,
+		teleportermessenger.ReceiptReceived:
+		if d.SourceBlockchainID == ids.Empty {
+			d.SourceBlockchainID = blockchainID
+		}
+	case teleportermessenger.ReceiveCrossChainMessage,
+		teleportermessenger.MessageExecuted,
+		teleportermessenger.MessageExecutionFailed:
+		if d.DestinationBlockchainID == ids.Empty {
+			d.DestinationBlockchainID = blockchainID
+		}
+	}
+}
+
+// decodeLog decodes a log emitted by the Teleporter contract, or a Warp message sent by the Teleporter
+// contract. Returns nil for any other log, or a Teleporter event that is not supported for decoding.
+func decodeLog(log *types.Log) (*decodedLog, error) {
+	switch log.Address {
+	case teleporterAddress:
+		return decodeTeleporterLog(log)
+	case warp.ContractAddress:
+		return decodeWarpLog(log)
+	default:
+		return nil, nil
+	}
+}
+
+func decodeTeleporterLog(log *types.Log) (*decodedLog, error) {
+	logger.Debug("Processing Teleporter log", zap.Any("log", log))
+
+	event, err := teleportermessenger.ParseLog(*log)
+	if errors.Is(err, teleportermessenger.ErrUnknownEvent) {
+		logger.Warn("Skipping unsupported Teleporter event",
+			zap.String("topic", log.Topics[0].Hex()),
+			zap.String("txHash", log.TxHash.Hex()))
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blockchainIDs := event.BlockchainIDs()
+	decoded := &decodedLog{
+		Output:                  newLogOutput(log, event.Kind().String(), teleporterEventFields(event)),
+		MessageID:               event.MessageID(),
+		SourceBlockchainID:      blockchainIDs.Source,
+		DestinationBlockchainID: blockchainIDs.Destination,
+	}
+	switch e := event.(type) {
+	case *teleportermessenger.SendCrossChainMessageEvent:
+		decoded.OriginSenderAddress = e.Message.OriginSenderAddress
+	case *teleportermessenger.ReceiveCrossChainMessageEvent:
+		decoded.OriginSenderAddress = e.Message.OriginSenderAddress
+	case *teleportermessenger.MessageExecutionFailedEvent:
+		decoded.OriginSenderAddress = e.Message.OriginSenderAddress
+	}
+	return decoded, nil
+}
+
+// teleporterEventFields returns the output fields of a Teleporter event. The metadata of the log the
+// event was decoded from is output as part of the log rather than its fields.
+func teleporterEventFields(event teleportermessenger.TeleporterEvent) map[string]interface{} {
+	fields := toOutputFields(event)
+	delete(fields, "metadata")
+	return fields
+}
+
+func decodeWarpLog(log *types.Log) (*decodedLog, error) {
+	// Only Warp messages sent by the Teleporter contract carry Teleporter messages.
+	if len(log.Topics) < 2 || common.BytesToAddress(log.Topics[1].Bytes()) != teleporterAddress {
+		return nil, nil
+	}
+	logger.Debug("Processing Warp log", zap.Any("log", log))
+
+	unsignedMsg, err := warp.UnpackSendWarpEventDataToMessage(log.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := warpPayload.ParseAddressedCall(unsignedMsg.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	teleporterMessage, err := teleportermessenger.UnpackTeleporterMessage(payload.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	warpMessageID := unsignedMsg.ID()
+	return &decodedLog{
+		Output: newLogOutput(log, sendWarpMessageEventName, map[string]interface{}{
+			"warpMessageID":     hexString(warpMessageID[:]),
+			"networkID":         unsignedMsg.NetworkID,
+			"sourceChainID":     unsignedMsg.SourceChainID.String(),
+			"sourceAddress":     common.BytesToAddress(payload.SourceAddress).Hex(),
+			"teleporterMessage": toOutputFields(teleporterMessage),
+		}),
+		SourceBlockchainID:      unsignedMsg.SourceChainID,
+		DestinationBlockchainID: teleporterMessage.DestinationBlockchainID,
+		OriginSenderAddress:     teleporterMessage.OriginSenderAddress,
+	}, nil
+}
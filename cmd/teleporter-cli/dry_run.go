@@ -0,0 +1,162 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleporterClient "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var dryRunArg bool
+
+// dryRunOutput is the output schema of a write command run with --dry-run.
+type dryRunOutput struct {
+	Transactions []dryRunTxOutput `json:"transactions" yaml:"transactions"`
+}
+
+// dryRunTxOutput is a transaction that a write command would submit. If it was simulated
+// successfully, it is signed and its raw bytes can be broadcast later with eth_sendRawTransaction.
+// GasLimit is the gas limit the command would set, which is at least EstimatedGas.
+type dryRunTxOutput struct {
+	From         string  `json:"from" yaml:"from"`
+	To           string  `json:"to" yaml:"to"`
+	Data         string  `json:"data" yaml:"data"`
+	Simulated    bool    `json:"simulated" yaml:"simulated"`
+	Success      bool    `json:"success" yaml:"success"`
+	RevertReason string  `json:"revertReason,omitempty" yaml:"revertReason,omitempty"`
+	Explanation  string  `json:"explanation,omitempty" yaml:"explanation,omitempty"`
+	EstimatedGas uint64  `json:"estimatedGas,omitempty" yaml:"estimatedGas,omitempty"`
+	GasLimit     uint64  `json:"gasLimit,omitempty" yaml:"gasLimit,omitempty"`
+	Nonce        *uint64 `json:"nonce,omitempty" yaml:"nonce,omitempty"`
+	TxHash       string  `json:"txHash,omitempty" yaml:"txHash,omitempty"`
+	RawTx        string  `json:"rawTx,omitempty" yaml:"rawTx,omitempty"`
+	Note         string  `json:"note,omitempty" yaml:"note,omitempty"`
+}
+
+// simulateTransaction runs the transaction that createAndSendTransaction would submit with
+// eth_call and eth_estimateGas. If it succeeds, the transaction is signed but not submitted.
+// A revert is reported in the output rather than returned as an error.
+func simulateTransaction(
+	ctx context.Context,
+	client ethclient.Client,
+	signer txSigner,
+	to common.Address,
+	data []byte,
+	minGasLimit uint64,
+) (*dryRunTxOutput, error) {
+	out := &dryRunTxOutput{
+		From:      signer.Address().Hex(),
+		To:        to.Hex(),
+		Data:      hexString(data),
+		Simulated: true,
+	}
+	msg := interfaces.CallMsg{
+		From: signer.Address(),
+		To:   &to,
+		Data: data,
+	}
+	if _, err := client.CallContract(ctx, msg, nil); err != nil {
+		reason, ok := teleporterClient.RevertReason(err)
+		if !ok {
+			return nil, fmt.Errorf("failed to call contract: %w", err)
+		}
+		out.RevertReason = reason
+		out.Explanation = explainRevert(reason)
+		return out, nil
+	}
+	estimatedGas, err := client.EstimateGas(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	out.Success = true
+	out.EstimatedGas = estimatedGas
+
+	gasLimit := estimatedGas
+	if gasLimit < minGasLimit {
+		gasLimit = minGasLimit
+	}
+	tx, err := createTransactionWithGasLimit(ctx, client, signer.Address(), to, data, gasLimit)
+	if err != nil {
+		return nil, err
+	}
+	if err := addSignedTx(ctx, out, signer, tx); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// unsimulatedTransaction signs the transaction without simulating it, for transactions whose
+// execution can't be simulated against the current state.
+func unsimulatedTransaction(
+	ctx context.Context,
+	signer txSigner,
+	tx *types.Transaction,
+	note string,
+) (*dryRunTxOutput, error) {
+	out := &dryRunTxOutput{
+		From: signer.Address().Hex(),
+		To:   tx.To().Hex(),
+		Data: hexString(tx.Data()),
+		Note: note,
+	}
+	if err := addSignedTx(ctx, out, signer, tx); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// addSignedTx signs the transaction and adds it to the output.
+func addSignedTx(ctx context.Context, out *dryRunTxOutput, signer txSigner, tx *types.Transaction) error {
+	signedTx, err := signer.SignTx(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	out.GasLimit = signedTx.Gas()
+	nonce := signedTx.Nonce()
+	out.Nonce = &nonce
+	out.TxHash = signedTx.Hash().Hex()
+	out.RawTx = hexString(raw)
+	return nil
+}
+
+// printDryRun prints the transactions of a write command run with --dry-run.
+func printDryRun(cmd *cobra.Command, txs ...*dryRunTxOutput) error {
+	out := dryRunOutput{Transactions: make([]dryRunTxOutput, 0, len(txs))}
+	for _, tx := range txs {
+		out.Transactions = append(out.Transactions, *tx)
+	}
+	if err := printOutput(cmd, out); err != nil {
+		return err
+	}
+	cmd.Println("Dry run completed, no transactions were submitted")
+	return nil
+}
+
+// simulateAndPrintTransaction simulates the transaction and prints it with printDryRun.
+func simulateAndPrintTransaction(
+	ctx context.Context,
+	cmd *cobra.Command,
+	client ethclient.Client,
+	signer txSigner,
+	to common.Address,
+	data []byte,
+	minGasLimit uint64,
+) error {
+	out, err := simulateTransaction(ctx, client, signer, to, data, minGasLimit)
+	if err != nil {
+		return err
+	}
+	return printDryRun(cmd, out)
+}
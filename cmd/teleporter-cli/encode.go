@@ -0,0 +1,187 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	networkIDArg     uint32
+	sourceChainIDArg string
+	sourceAddressArg string
+)
+
+var encodeCmd = &cobra.Command{
+	Use:   "encode [--network-id NETWORK_ID --source-chain-id CHAIN_ID --source-address ADDRESS] FILE",
+	Short: "Encodes a Teleporter message described in a JSON or YAML file",
+	Long: `Given a JSON or YAML file describing a Teleporter message, this command will
+ABI encode the message and print its hex encoded bytes. The file uses the same
+fields and encoding as the output of the message command, i.e. CB58 encoded
+blockchain IDs, hex encoded addresses and bytes, and decimal integers. If FILE
+is -, the description is read from stdin. If a network ID, source chain ID and
+source address are provided, the message is also wrapped in an AddressedCall
+payload from the source address and an unsigned Warp message.`,
+	Args: cobra.ExactArgs(1),
+	RunE: encodeRunE,
+}
+
+// encodeOutput is the output schema of the encode command.
+type encodeOutput struct {
+	TeleporterMessage   string `json:"teleporterMessage" yaml:"teleporterMessage"`
+	AddressedCall       string `json:"addressedCall,omitempty" yaml:"addressedCall,omitempty"`
+	UnsignedWarpMessage string `json:"unsignedWarpMessage,omitempty" yaml:"unsignedWarpMessage,omitempty"`
+	WarpMessageID       string `json:"warpMessageID,omitempty" yaml:"warpMessageID,omitempty"`
+}
+
+func encodeRunE(cmd *cobra.Command, args []string) error {
+	var (
+		b   []byte
+		err error
+	)
+	if args[0] == "-" {
+		b, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		b, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	msg, err := parseTeleporterMessage(b)
+	if err != nil {
+		return err
+	}
+
+	msgBytes, err := teleportermessenger.PackTeleporterMessage(msg)
+	if err != nil {
+		return err
+	}
+	out := encodeOutput{
+		TeleporterMessage: hexString(msgBytes),
+	}
+
+	if cmd.Flags().Changed("network-id") {
+		sourceChainID, err := parseID(sourceChainIDArg)
+		if err != nil {
+			return err
+		}
+		sourceAddress, err := parseAddress(sourceAddressArg)
+		if err != nil {
+			return err
+		}
+		addressedCall, err := warpPayload.NewAddressedCall(sourceAddress.Bytes(), msgBytes)
+		if err != nil {
+			return err
+		}
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkIDArg, sourceChainID, addressedCall.Bytes())
+		if err != nil {
+			return err
+		}
+		warpMessageID := unsignedMsg.ID()
+		out.AddressedCall = hexString(addressedCall.Bytes())
+		out.UnsignedWarpMessage = hexString(unsignedMsg.Bytes())
+		out.WarpMessageID = hexString(warpMessageID[:])
+	}
+
+	if err := printOutput(cmd, out); err != nil {
+		return err
+	}
+	cmd.Println("Encode command ran successfully")
+	return nil
+}
+
+// parseTeleporterMessage parses the JSON or YAML description of a Teleporter message, in the JSON
+// encoding of TeleporterMessage. JSON is a subset of YAML, so the description is parsed as YAML and
+// converted to JSON.
+func parseTeleporterMessage(b []byte) (teleportermessenger.TeleporterMessage, error) {
+	var msg teleportermessenger.TeleporterMessage
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return msg, fmt.Errorf("failed to parse Teleporter message: %w", err)
+	}
+	value, err := yamlToJSONValue(&node)
+	if err != nil {
+		return msg, fmt.Errorf("failed to parse Teleporter message: %w", err)
+	}
+	b, err = json.Marshal(value)
+	if err != nil {
+		return msg, err
+	}
+	if err := json.Unmarshal(b, &msg); err != nil {
+		return msg, fmt.Errorf("failed to parse Teleporter message: %w", err)
+	}
+	// Reject descriptions with missing integer fields, which can not be packed.
+	err = teleportermessenger.ValidateTeleporterMessage(msg, teleportermessenger.ValidationLimits{})
+	return msg, err
+}
+
+// yamlToJSONValue converts a YAML node into a value to encode as JSON. Scalars other than booleans
+// and nulls are converted to strings, so that unquoted integers and hex strings keep their text, as
+// the JSON encoding of TeleporterMessage expects.
+func yamlToJSONValue(node *yaml.Node) (interface{}, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return yamlToJSONValue(node.Content[0])
+	case yaml.AliasNode:
+		return yamlToJSONValue(node.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := yamlToJSONValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[node.Content[i].Value] = value
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		s := make([]interface{}, len(node.Content))
+		for i, elem := range node.Content {
+			value, err := yamlToJSONValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = value
+		}
+		return s, nil
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!null":
+			return nil, nil
+		case "!!bool":
+			var b bool
+			if err := node.Decode(&b); err != nil {
+				return nil, err
+			}
+			return b, nil
+		default:
+			return node.Value, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported YAML node at line %d", node.Line)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(encodeCmd)
+	encodeCmd.Flags().Uint32Var(&networkIDArg, "network-id", 0, "Network ID of the unsigned Warp message")
+	encodeCmd.Flags().StringVar(&sourceChainIDArg, "source-chain-id", "",
+		"Source chain ID of the unsigned Warp message, CB58 or hex encoded")
+	encodeCmd.Flags().StringVar(&sourceAddressArg, "source-address", "",
+		"Source address of the AddressedCall payload, i.e. the Teleporter contract address")
+	encodeCmd.MarkFlagsRequiredTogether("network-id", "source-chain-id", "source-address")
+}
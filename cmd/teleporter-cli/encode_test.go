@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeCmd(t *testing.T) {
+	messageFile := filepath.Join(t.TempDir(), "message.yaml")
+	err := os.WriteFile(messageFile, []byte(`
+messageNonce: 1
+originSenderAddress: "0x0000000000000000000000000000000000000001"
+destinationBlockchainID: "`+ids.ID(common.HexToHash("0x2")).String()+`"
+destinationAddress: "0x0000000000000000000000000000000000000003"
+requiredGasLimit: 100000
+allowedRelayerAddresses: []
+receipts:
+  - receivedMessageNonce: 4
+    relayerRewardAddress: "0x0000000000000000000000000000000000000005"
+message: "0x0102"
+`), 0o600)
+	require.NoError(t, err)
+
+	// rewriteMessageFile writes a copy of the message file with the given field replaced.
+	rewriteMessageFile := func(name string, field string, replacement string) string {
+		b, err := os.ReadFile(messageFile)
+		require.NoError(t, err)
+		b = regexp.MustCompile(`(?m)^(\s*(- )?)`+field+`: .*$`).ReplaceAll(b, []byte("${1}"+replacement))
+		path := filepath.Join(t.TempDir(), name+".yaml")
+		require.NoError(t, os.WriteFile(path, b, 0o600))
+		return path
+	}
+
+	// Negative integers are encoded as two's complement by the ABI packer, so must be rejected.
+	negativeFiles := make(map[string]string)
+	for _, field := range []string{"messageNonce", "requiredGasLimit", "receivedMessageNonce"} {
+		negativeFiles[field] = rewriteMessageFile(field, field, field+": -1")
+	}
+	missingNonceFile := rewriteMessageFile("missing", "messageNonce", "")
+	hexBlockchainIDFile := rewriteMessageFile("hex", "destinationBlockchainID",
+		`destinationBlockchainID: "`+common.HexToHash("0x2").Hex()+`"`)
+
+	message := teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		OriginSenderAddress:     common.HexToAddress("0x1"),
+		DestinationBlockchainID: common.HexToHash("0x2"),
+		DestinationAddress:      common.HexToAddress("0x3"),
+		RequiredGasLimit:        big.NewInt(100000),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts: []teleportermessenger.TeleporterMessageReceipt{
+			{
+				ReceivedMessageNonce: big.NewInt(4),
+				RelayerRewardAddress: common.HexToAddress("0x5"),
+			},
+		},
+		Message: []byte{1, 2},
+	}
+	expected, err := teleportermessenger.PackTeleporterMessage(message)
+	require.NoError(t, err)
+
+	// The JSON encoding of the message is also accepted.
+	jsonFile := filepath.Join(t.TempDir(), "message.json")
+	b, err := json.Marshal(message)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(jsonFile, b, 0o600))
+
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"encode"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+		{
+			name: "help",
+			args: []string{"encode", "--help"},
+			err:  nil,
+			out:  "Given a JSON or YAML file describing a Teleporter message",
+		},
+		{
+			name: "yaml file",
+			args: []string{"encode", messageFile},
+			err:  nil,
+			out:  hexString(expected),
+		},
+		{
+			name: "json file",
+			args: []string{"encode", jsonFile},
+			err:  nil,
+			out:  hexString(expected),
+		},
+		{
+			name: "negative message nonce",
+			args: []string{"encode", negativeFiles["messageNonce"]},
+			err:  fmt.Errorf(`invalid uint256 "-1"`),
+		},
+		{
+			name: "negative required gas limit",
+			args: []string{"encode", negativeFiles["requiredGasLimit"]},
+			err:  fmt.Errorf(`invalid uint256 "-1"`),
+		},
+		{
+			name: "negative receipt nonce",
+			args: []string{"encode", negativeFiles["receivedMessageNonce"]},
+			err:  fmt.Errorf(`invalid uint256 "-1"`),
+		},
+		{
+			name: "missing message nonce",
+			args: []string{"encode", missingNonceFile},
+			err:  fmt.Errorf("messageNonce: missing field"),
+		},
+		{
+			// Blockchain IDs are CB58 encoded, as in the output of the message command.
+			name: "hex blockchain ID",
+			args: []string{"encode", hexBlockchainIDFile},
+			err:  fmt.Errorf("failed to parse Teleporter message"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
@@ -4,10 +4,10 @@
 package main
 
 import (
+	"github.com/ava-labs/subnet-evm/core/types"
 	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
-	"go.uber.org/zap"
 )
 
 var (
@@ -31,13 +31,14 @@ func eventRun(cmd *cobra.Command, args []string) {
 		topics = append(topics, common.HexToHash(topic))
 	}
 
-	event, err := teleporterABI.EventByID(topics[0])
+	event, err := teleportermessenger.ParseLog(types.Log{Topics: topics, Data: data})
 	cobra.CheckErr(err)
-
-	out, err := teleportermessenger.FilterTeleporterEvents(topics, data, event.Name)
+	err = printOutput(cmd, logOutput{
+		Event:  event.Kind().String(),
+		Fields: teleporterEventFields(event),
+	})
 	cobra.CheckErr(err)
-	logger.Info("Parsed Teleporter event", zap.String("name", event.Name), zap.Any("event", out))
-	cmd.Println("Event command ran successfully for", event.Name)
+	cmd.Println("Event command ran successfully for", event.Kind())
 }
 
 func init() {
@@ -0,0 +1,220 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	receiveTeleporterMessageSignature = "receiveTeleporterMessage(bytes32,address,bytes)"
+	outOfGasError                     = "out of gas"
+)
+
+var receiveTeleporterMessageSelector = crypto.Keccak256([]byte(receiveTeleporterMessageSignature))[:4]
+
+var explainCmd = &cobra.Command{
+	Use:   "explain --rpc RPC_URL --teleporter-address CONTRACT_ADDRESS TRANSACTION_HASH",
+	Short: "Explains why a Teleporter transaction failed",
+	Long: `Given a transaction this command traces it with debug_traceTransaction and the
+callTracer, and explains the revert reason of the transaction if it failed. Each
+call frame that reverted or ran out of gas is listed, and the frames in which a
+receiver's receiveTeleporterMessage failed are highlighted, since the transaction
+itself succeeds when message execution fails. The RPC endpoint must support the
+debug API.`,
+	Args: cobra.ExactArgs(1),
+	RunE: explainRunE,
+}
+
+// callFrame is a call frame of the callTracer's result.
+type callFrame struct {
+	Type         string         `json:"type"`
+	From         common.Address `json:"from"`
+	To           common.Address `json:"to"`
+	Input        hexutil.Bytes  `json:"input"`
+	Output       hexutil.Bytes  `json:"output"`
+	Gas          hexutil.Uint64 `json:"gas"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Error        string         `json:"error"`
+	RevertReason string         `json:"revertReason"`
+	Calls        []callFrame    `json:"calls"`
+}
+
+// explainOutput is the output schema of the explain command.
+type explainOutput struct {
+	TxHash       string              `json:"txHash" yaml:"txHash"`
+	Status       uint64              `json:"status" yaml:"status"`
+	Method       string              `json:"method,omitempty" yaml:"method,omitempty"`
+	Error        string              `json:"error,omitempty" yaml:"error,omitempty"`
+	RevertReason string              `json:"revertReason,omitempty" yaml:"revertReason,omitempty"`
+	Explanation  string              `json:"explanation" yaml:"explanation"`
+	FailedFrames []failedFrameOutput `json:"failedFrames" yaml:"failedFrames"`
+}
+
+// failedFrameOutput is the output schema of a call frame that reverted or ran out of gas.
+type failedFrameOutput struct {
+	Depth                    int    `json:"depth" yaml:"depth"`
+	Type                     string `json:"type" yaml:"type"`
+	From                     string `json:"from" yaml:"from"`
+	To                       string `json:"to" yaml:"to"`
+	Method                   string `json:"method,omitempty" yaml:"method,omitempty"`
+	Gas                      uint64 `json:"gas" yaml:"gas"`
+	GasUsed                  uint64 `json:"gasUsed" yaml:"gasUsed"`
+	Error                    string `json:"error" yaml:"error"`
+	RevertReason             string `json:"revertReason,omitempty" yaml:"revertReason,omitempty"`
+	ReceiveTeleporterMessage bool   `json:"receiveTeleporterMessage" yaml:"receiveTeleporterMessage"`
+	Explanation              string `json:"explanation" yaml:"explanation"`
+}
+
+func explainRunE(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	txHash := common.HexToHash(args[0])
+	receipt, err := client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return err
+	}
+
+	trace, err := traceTransaction(ctx, txHash)
+	if err != nil {
+		return err
+	}
+
+	out := explainOutput{
+		TxHash:       txHash.Hex(),
+		Status:       receipt.Status,
+		Method:       methodName(trace),
+		Error:        trace.Error,
+		RevertReason: revertReason(trace),
+		FailedFrames: []failedFrameOutput{},
+	}
+	collectFailedFrames(trace, 0, &out.FailedFrames)
+
+	switch {
+	case receipt.Status == types.ReceiptStatusFailed && trace.Error == outOfGasError:
+		out.Explanation = "The transaction ran out of gas. Resubmit it with a higher gas limit."
+	case receipt.Status == types.ReceiptStatusFailed:
+		out.Explanation = explainRevert(out.RevertReason)
+	case hasFailedReceiveTeleporterMessage(out.FailedFrames):
+		out.Explanation = "The transaction succeeded, but the execution of a delivered message failed. " +
+			"See the highlighted receiveTeleporterMessage frames."
+	default:
+		out.Explanation = "The transaction succeeded."
+	}
+
+	if err := printOutput(cmd, out); err != nil {
+		return err
+	}
+	cmd.Println("Explain command ran successfully")
+	return nil
+}
+
+// traceTransaction traces the transaction with the callTracer.
+func traceTransaction(ctx context.Context, txHash common.Hash) (*callFrame, error) {
+	rpcClient, err := rpc.DialContext(ctx, rpcEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer rpcClient.Close()
+
+	var trace callFrame
+	err = rpcClient.CallContext(ctx, &trace, "debug_traceTransaction", txHash, map[string]string{
+		"tracer": "callTracer",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to trace transaction: %w", err)
+	}
+	return &trace, nil
+}
+
+// collectFailedFrames appends the frames of the call tree rooted at frame that reverted or ran out of gas.
+func collectFailedFrames(frame *callFrame, depth int, failed *[]failedFrameOutput) {
+	if frame.Error != "" {
+		isReceive := isReceiveTeleporterMessage(frame)
+		reason := revertReason(frame)
+		*failed = append(*failed, failedFrameOutput{
+			Depth:                    depth,
+			Type:                     frame.Type,
+			From:                     frame.From.Hex(),
+			To:                       frame.To.Hex(),
+			Method:                   methodName(frame),
+			Gas:                      uint64(frame.Gas),
+			GasUsed:                  uint64(frame.GasUsed),
+			Error:                    frame.Error,
+			RevertReason:             reason,
+			ReceiveTeleporterMessage: isReceive,
+			Explanation:              explainFrame(frame, isReceive, reason),
+		})
+	}
+	for i := range frame.Calls {
+		collectFailedFrames(&frame.Calls[i], depth+1, failed)
+	}
+}
+
+func explainFrame(frame *callFrame, isReceive bool, reason string) string {
+	switch {
+	case isReceive && frame.Error == outOfGasError:
+		return "The receiver's receiveTeleporterMessage ran out of gas within the message's required gas limit. " +
+			"The message was stored as failed, and can be executed with retryMessageExecution, which " +
+			"provides all of the transaction's remaining gas."
+	case isReceive:
+		return fmt.Sprintf("The receiver's receiveTeleporterMessage reverted: %s The message was stored as "+
+			"failed, and can be executed with retryMessageExecution once the cause is addressed.",
+			explainRevert(reason))
+	case frame.Error == outOfGasError:
+		return "The call ran out of gas."
+	default:
+		return explainRevert(reason)
+	}
+}
+
+func hasFailedReceiveTeleporterMessage(frames []failedFrameOutput) bool {
+	for _, frame := range frames {
+		if frame.ReceiveTeleporterMessage {
+			return true
+		}
+	}
+	return false
+}
+
+func isReceiveTeleporterMessage(frame *callFrame) bool {
+	return len(frame.Input) >= 4 && bytes.Equal(frame.Input[:4], receiveTeleporterMessageSelector)
+}
+
+// revertReason returns the revert reason of the frame, unpacking it from the frame's output if the
+// tracer did not.
+func revertReason(frame *callFrame) string {
+	if frame.RevertReason != "" {
+		return frame.RevertReason
+	}
+	return unpackRevertReason(frame.Output)
+}
+
+// methodName returns the name of the Teleporter method called by the frame, if known.
+func methodName(frame *callFrame) string {
+	if isReceiveTeleporterMessage(frame) {
+		return "receiveTeleporterMessage"
+	}
+	if frame.To != teleporterAddress || len(frame.Input) < 4 {
+		return ""
+	}
+	method, err := teleporterABI.MethodById(frame.Input[:4])
+	if err != nil {
+		return ""
+	}
+	return method.Name
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+	addClientFlags(explainCmd)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"explain"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+		{
+			name: "help",
+			args: []string{"explain", "--help"},
+			err:  nil,
+			out:  "Given a transaction this command traces it with debug_traceTransaction and the",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestMethodName(t *testing.T) {
+	setTestGlobals(t, common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"))
+	sendSelector := teleporterABI.Methods["sendCrossChainMessage"].ID
+	receiver := common.HexToAddress("0x1")
+	// withArgs returns the calldata of a call to selector with an argument word.
+	withArgs := func(selector []byte) []byte {
+		return append(append([]byte{}, selector...), make([]byte, 32)...)
+	}
+
+	var tests = []struct {
+		name  string
+		frame callFrame
+		out   string
+	}{
+		{
+			name:  "teleporter method",
+			frame: callFrame{To: teleporterAddress, Input: withArgs(sendSelector)},
+			out:   "sendCrossChainMessage",
+		},
+		{
+			name:  "selector only",
+			frame: callFrame{To: teleporterAddress, Input: sendSelector},
+			out:   "sendCrossChainMessage",
+		},
+		{
+			// The receiver is called by the Teleporter contract, so is identified by its selector.
+			name:  "receive teleporter message",
+			frame: callFrame{To: receiver, Input: withArgs(receiveTeleporterMessageSelector)},
+			out:   "receiveTeleporterMessage",
+		},
+		{
+			name:  "other contract",
+			frame: callFrame{To: receiver, Input: sendSelector},
+			out:   "",
+		},
+		{
+			name:  "short input",
+			frame: callFrame{To: teleporterAddress, Input: sendSelector[:3]},
+			out:   "",
+		},
+		{
+			name:  "unknown selector",
+			frame: callFrame{To: teleporterAddress, Input: []byte{1, 2, 3, 4}},
+			out:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.out, methodName(&tt.frame))
+		})
+	}
+}
+
+func TestRevertReason(t *testing.T) {
+	reason := "TeleporterMessenger: unauthorized relayer"
+
+	// The reason decoded by the tracer is preferred over unpacking the output.
+	frame := callFrame{RevertReason: reason, Output: packRevert(t, "other")}
+	require.Equal(t, reason, revertReason(&frame))
+
+	frame = callFrame{Output: packRevert(t, reason)}
+	require.Equal(t, reason, revertReason(&frame))
+
+	frame = callFrame{}
+	require.Equal(t, "", revertReason(&frame))
+}
@@ -0,0 +1,166 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	idTeleporterAddressArg       string
+	idSourceBlockchainIDArg      string
+	idDestinationBlockchainIDArg string
+	idNonceArg                   string
+
+	errZeroMessageNonce = errors.New("message nonce must be non-zero, nonces start at 1")
+)
+
+var idCmd = &cobra.Command{
+	Use: "id --teleporter-address CONTRACT_ADDRESS --source-blockchain-id BLOCKCHAIN_ID " +
+		"--destination-blockchain-id BLOCKCHAIN_ID --nonce NONCE",
+	Short: "Computes the ID of a Teleporter message",
+	Long: `Given the address of the Teleporter contract on the source blockchain, the
+source and destination blockchain IDs and a message nonce, this command computes
+the message's ID offline, matching the contract's calculateMessageID. The next
+subcommand predicts the ID of the next message sent to a destination blockchain
+by calling getNextMessageID, which is useful for adding fees to a message or
+correlating its logs before it is sent.`,
+	Args: cobra.NoArgs,
+	RunE: idRunE,
+}
+
+var idNextCmd = &cobra.Command{
+	Use:   "next --rpc RPC_URL --teleporter-address CONTRACT_ADDRESS --destination-blockchain-id BLOCKCHAIN_ID",
+	Short: "Predicts the ID of the next message sent to a destination blockchain",
+	Long: `Given a destination blockchain ID, this command calls getNextMessageID on the
+connected chain to predict the ID of the next message sent to the destination
+blockchain, along with its nonce. The ID only holds until another message is
+sent from the connected chain, to any destination.`,
+	Args: cobra.NoArgs,
+	RunE: idNextRunE,
+}
+
+// messageIDOutput is the output schema of the id command.
+type messageIDOutput struct {
+	MessageID               string `json:"messageID" yaml:"messageID"`
+	TeleporterAddress       string `json:"teleporterAddress" yaml:"teleporterAddress"`
+	SourceBlockchainID      string `json:"sourceBlockchainID" yaml:"sourceBlockchainID"`
+	DestinationBlockchainID string `json:"destinationBlockchainID" yaml:"destinationBlockchainID"`
+	Nonce                   string `json:"nonce" yaml:"nonce"`
+}
+
+func idRunE(cmd *cobra.Command, args []string) error {
+	address, err := parseAddress(idTeleporterAddressArg)
+	if err != nil {
+		return err
+	}
+	sourceBlockchainID, err := parseID(idSourceBlockchainIDArg)
+	if err != nil {
+		return err
+	}
+	destinationBlockchainID, err := parseID(idDestinationBlockchainIDArg)
+	if err != nil {
+		return err
+	}
+	nonce, err := parseUint256(idNonceArg)
+	if err != nil {
+		return err
+	}
+	if nonce.Sign() <= 0 {
+		return errZeroMessageNonce
+	}
+
+	messageID, err := teleportermessenger.CalculateMessageID(
+		address, sourceBlockchainID, destinationBlockchainID, nonce,
+	)
+	if err != nil {
+		return err
+	}
+	err = printOutput(cmd, messageIDOutput{
+		MessageID:               common.Hash(messageID).Hex(),
+		TeleporterAddress:       address.Hex(),
+		SourceBlockchainID:      sourceBlockchainID.String(),
+		DestinationBlockchainID: destinationBlockchainID.String(),
+		Nonce:                   nonce.String(),
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Println("ID command ran successfully")
+	return nil
+}
+
+func idNextRunE(cmd *cobra.Command, args []string) error {
+	destinationBlockchainID, err := parseID(idDestinationBlockchainIDArg)
+	if err != nil {
+		return err
+	}
+
+	messenger, err := teleportermessenger.NewTeleporterMessengerCaller(teleporterAddress, client)
+	if err != nil {
+		return err
+	}
+	// Read the message ID, nonce and blockchain ID at the same block.
+	ctx := context.Background()
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: header.Number}
+	messageID, err := messenger.GetNextMessageID(opts, destinationBlockchainID)
+	if err != nil {
+		return err
+	}
+	blockchainID, err := messenger.BlockchainID(opts)
+	if err != nil {
+		return err
+	}
+	messageNonce, err := messenger.MessageNonce(opts)
+	if err != nil {
+		return err
+	}
+
+	err = printOutput(cmd, messageIDOutput{
+		MessageID:               common.Hash(messageID).Hex(),
+		TeleporterAddress:       teleporterAddress.Hex(),
+		SourceBlockchainID:      ids.ID(blockchainID).String(),
+		DestinationBlockchainID: destinationBlockchainID.String(),
+		Nonce:                   new(big.Int).Add(messageNonce, big.NewInt(1)).String(),
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Println("ID next command ran successfully")
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(idCmd)
+	idCmd.Flags().StringVarP(&idTeleporterAddressArg, "teleporter-address", "t", "",
+		"Teleporter contract address on the source blockchain")
+	idCmd.Flags().StringVar(&idSourceBlockchainIDArg, "source-blockchain-id", "",
+		"Source blockchain ID, CB58 or hex encoded")
+	idCmd.Flags().StringVar(&idDestinationBlockchainIDArg, "destination-blockchain-id", "",
+		"Destination blockchain ID, CB58 or hex encoded")
+	idCmd.Flags().StringVar(&idNonceArg, "nonce", "", "Nonce of the message")
+	for _, flag := range []string{"teleporter-address", "source-blockchain-id", "destination-blockchain-id", "nonce"} {
+		err := idCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+
+	idCmd.AddCommand(idNextCmd)
+	addClientFlags(idNextCmd)
+	idNextCmd.Flags().StringVar(&idDestinationBlockchainIDArg, "destination-blockchain-id", "",
+		"Destination blockchain ID, CB58 or hex encoded")
+	err := idNextCmd.MarkFlagRequired("destination-blockchain-id")
+	cobra.CheckErr(err)
+}
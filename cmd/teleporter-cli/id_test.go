@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"id"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"id", "--help"},
+			err:  nil,
+			out:  "Given the address of the Teleporter contract on the source blockchain, the",
+		},
+		{
+			name: "zero nonce",
+			args: []string{
+				"id",
+				"--teleporter-address", "0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf",
+				"--source-blockchain-id", ids.Empty.String(),
+				"--destination-blockchain-id", ids.Empty.String(),
+				"--nonce", "0",
+			},
+			err: errZeroMessageNonce,
+		},
+		{
+			name: "next no args",
+			args: []string{"id", "next"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "next help",
+			args: []string{"id", "next", "--help"},
+			err:  nil,
+			out:  "Given a destination blockchain ID, this command calls getNextMessageID on the",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
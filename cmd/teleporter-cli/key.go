@@ -0,0 +1,177 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	privateKeyEnvVar       = "TELEPORTER_CLI_PRIVATE_KEY"
+	keystorePasswordEnvVar = "TELEPORTER_CLI_KEYSTORE_PASSWORD"
+)
+
+var (
+	// keyArgs is the key source provided with flags, and configKey the key source of the chain
+	// selected from the config file.
+	keyArgs   keySource
+	configKey keySource
+
+	passwordFileArg  string
+	remoteSignerArg  string
+	signerAddressArg string
+
+	errNoPasswordInput = fmt.Errorf(
+		"no keystore password provided, set --password-file or the %s environment variable, "+
+			"or run in a terminal to be prompted", keystorePasswordEnvVar,
+	)
+	errNoSigner = fmt.Errorf(
+		"no signer provided, set --private-key, --key-file, --keystore, --remote-signer, "+
+			"a key in the config file or the %s environment variable", privateKeyEnvVar,
+	)
+	errInvalidSignerAddress = errors.New("invalid --signer-address")
+)
+
+// keySource is a source of the private key that signs transactions. If more than one field is set,
+// the keystore takes precedence over the key file, and the key file over the private key.
+type keySource struct {
+	privateKey string
+	keyFile    string
+	keystore   string
+}
+
+func (k keySource) isEmpty() bool {
+	return k == keySource{}
+}
+
+// addKeyFlags registers the flags used to provide the signer of transactions, and the --dry-run flag.
+func addKeyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&keyArgs.privateKey, "private-key", "", "Hex encoded private key used to sign transactions")
+	cmd.Flags().StringVar(&keyArgs.keyFile, "key-file", "", "Path to a file containing a hex encoded private key")
+	cmd.Flags().StringVar(&keyArgs.keystore, "keystore", "", "Path to an encrypted keystore JSON file")
+	cmd.Flags().StringVar(&passwordFileArg, "password-file", "",
+		"Path to a file containing the keystore password. Prompted for if not set")
+	cmd.Flags().StringVar(&remoteSignerArg, "remote-signer", "",
+		"URL of a JSON-RPC endpoint that signs transactions with eth_signTransaction")
+	cmd.Flags().StringVar(&signerAddressArg, "signer-address", "",
+		"Address the remote signer signs with. Defaults to its only account")
+	cmd.Flags().BoolVar(&dryRunArg, "dry-run", false,
+		"Simulate the transactions with eth_call and eth_estimateGas, and print them signed instead of submitting them")
+	cmd.MarkFlagsMutuallyExclusive("private-key", "key-file", "keystore", "remote-signer")
+}
+
+// loadSigner returns the signer of transactions from the --private-key, --key-file, --keystore or
+// --remote-signer flags, the key of the chain selected from the config file, or the
+// TELEPORTER_CLI_PRIVATE_KEY environment variable, in that order of precedence.
+func loadSigner(ctx context.Context) (txSigner, error) {
+	return loadSignerForKey(ctx, configKey)
+}
+
+// loadSignerForKey is loadSigner with chainKey in place of the key of the chain selected from the
+// config file, for commands that sign transactions on several chains of the config file.
+func loadSignerForKey(ctx context.Context, chainKey keySource) (txSigner, error) {
+	if remoteSignerArg != "" {
+		var address common.Address
+		if signerAddressArg != "" {
+			if !common.IsHexAddress(signerAddressArg) {
+				return nil, errInvalidSignerAddress
+			}
+			address = common.HexToAddress(signerAddressArg)
+		}
+		return newRemoteSigner(ctx, remoteSignerArg, address)
+	}
+
+	source := keyArgs
+	if source.isEmpty() {
+		source = chainKey
+	}
+	if source.isEmpty() {
+		source.privateKey = os.Getenv(privateKeyEnvVar)
+	}
+	if source.isEmpty() {
+		return nil, errNoSigner
+	}
+	key, err := source.load()
+	if err != nil {
+		return nil, err
+	}
+	return newKeySigner(key), nil
+}
+
+// load reads the private key from its source, prompting for the keystore password if needed.
+func (k keySource) load() (*ecdsa.PrivateKey, error) {
+	if k.keystore != "" {
+		return loadKeystore(k.keystore)
+	}
+
+	keyHex := k.privateKey
+	if k.keyFile != "" {
+		b, err := os.ReadFile(k.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+		keyHex = string(b)
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(keyHex), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return key, nil
+}
+
+// loadKeystore decrypts the private key of the encrypted keystore JSON file at the given path.
+func loadKeystore(path string) (*ecdsa.PrivateKey, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+	password, err := readKeystorePassword(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore %s: %w", path, err)
+	}
+	return key.PrivateKey, nil
+}
+
+// readKeystorePassword returns the keystore password from the --password-file flag or the
+// TELEPORTER_CLI_KEYSTORE_PASSWORD environment variable, or prompts for it on the terminal.
+func readKeystorePassword(path string) (string, error) {
+	if passwordFileArg != "" {
+		b, err := os.ReadFile(passwordFileArg)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password file: %w", err)
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+	if password, ok := os.LookupEnv(keystorePasswordEnvVar); ok {
+		return password, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errNoPasswordInput
+	}
+	// The prompt is written to stderr, so that stdout only contains the command's result.
+	fmt.Fprintf(os.Stderr, "Password for keystore %s: ", path)
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return string(password), nil
+}
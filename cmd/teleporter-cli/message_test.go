@@ -1,13 +1,29 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
 func TestMessageCmd(t *testing.T) {
+	destinationBlockchainID := ids.ID{1, 2, 3}
+	msgBytes, err := teleportermessenger.PackTeleporterMessage(teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		DestinationBlockchainID: destinationBlockchainID,
+		RequiredGasLimit:        big.NewInt(100_000),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 []byte{1, 2},
+	})
+	require.NoError(t, err)
+
 	var tests = []struct {
 		name string
 		args []string
@@ -25,6 +41,13 @@ func TestMessageCmd(t *testing.T) {
 			err:  nil,
 			out:  "Given the hex encoded bytes of a Teleporter message",
 		},
+		{
+			// Blockchain IDs are CB58 encoded, as in the JSON encoding of the message.
+			name: "message",
+			args: []string{"message", hex.EncodeToString(msgBytes)},
+			err:  nil,
+			out:  "destinationBlockchainID  " + destinationBlockchainID.String(),
+		},
 	}
 
 	for _, tt := range tests {
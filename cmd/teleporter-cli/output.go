@@ -0,0 +1,251 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputTable = "table"
+)
+
+var (
+	outputFormat string
+
+	bigIntType  = reflect.TypeOf(&big.Int{})
+	addressType = reflect.TypeOf(common.Address{})
+)
+
+// logOutput is the output schema of a decoded Teleporter or Warp log. The log's location is only
+// included when it is known, e.g. not when decoding topics and data directly.
+type logOutput struct {
+	Event       string                 `json:"event" yaml:"event"`
+	Address     string                 `json:"address,omitempty" yaml:"address,omitempty"`
+	TxHash      string                 `json:"txHash,omitempty" yaml:"txHash,omitempty"`
+	BlockNumber *uint64                `json:"blockNumber,omitempty" yaml:"blockNumber,omitempty"`
+	LogIndex    *uint                  `json:"logIndex,omitempty" yaml:"logIndex,omitempty"`
+	Fields      map[string]interface{} `json:"fields" yaml:"fields"`
+}
+
+func newLogOutput(log *types.Log, event string, fields map[string]interface{}) logOutput {
+	blockNumber := log.BlockNumber
+	logIndex := log.Index
+	return logOutput{
+		Event:       event,
+		Address:     log.Address.Hex(),
+		TxHash:      log.TxHash.Hex(),
+		BlockNumber: &blockNumber,
+		LogIndex:    &logIndex,
+		Fields:      fields,
+	}
+}
+
+func validateOutputFormat(format string) error {
+	switch format {
+	case outputJSON, outputYAML, outputTable:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %s, must be one of %s, %s or %s",
+			format, outputJSON, outputYAML, outputTable)
+	}
+}
+
+// printOutput writes v to the command's standard output in the format selected by --output.
+func printOutput(cmd *cobra.Command, v interface{}) error {
+	w := cmd.OutOrStdout()
+	switch outputFormat {
+	case outputJSON:
+		return writeJSON(w, v)
+	case outputYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return writeTable(w, v)
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeTable writes v as rows of keys and values, flattening nested fields into dot separated keys.
+func writeTable(w io.Writer, v interface{}) error {
+	// Round trip through JSON so that struct tags determine the keys of the table.
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&generic); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, row := range flatten("", generic) {
+		fmt.Fprintf(tw, "%s\t%s\n", row[0], row[1])
+	}
+	return tw.Flush()
+}
+
+func flatten(prefix string, v interface{}) [][2]string {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+
+	var rows [][2]string
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			rows = append(rows, flatten(join(key), val[key])...)
+		}
+	case []interface{}:
+		if len(val) == 0 {
+			rows = append(rows, [2]string{prefix, "[]"})
+		}
+		for i, elem := range val {
+			rows = append(rows, flatten(join(strconv.Itoa(i)), elem)...)
+		}
+	case nil:
+		rows = append(rows, [2]string{prefix, ""})
+	default:
+		rows = append(rows, [2]string{prefix, fmt.Sprint(val)})
+	}
+	return rows
+}
+
+// toOutputFields converts an ABI decoded struct into its output representation. Values with a JSON
+// encoding, such as the Teleporter structs and events, are output as that encoding, so that for
+// example blockchain IDs are CB58 encoded. Otherwise byte arrays and slices are hex encoded, big
+// integers are decimal strings, addresses are checksummed hex, and structs become maps keyed by
+// their field names with the first letter lower cased. The Raw log field of abigen event structs
+// is omitted, and the fields of embedded structs are flattened.
+func toOutputFields(v interface{}) map[string]interface{} {
+	fields, ok := toOutputValue(reflect.ValueOf(v)).(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+	return fields
+}
+
+func toOutputValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil
+	}
+
+	switch v.Type() {
+	case bigIntType:
+		return v.Interface().(*big.Int).String()
+	case addressType:
+		return v.Interface().(common.Address).Hex()
+	}
+
+	if v.CanInterface() {
+		if marshaler, ok := v.Interface().(json.Marshaler); ok {
+			if out, err := toJSONOutputValue(marshaler); err == nil {
+				return out
+			}
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return toOutputValue(v.Elem())
+	case reflect.Struct:
+		fields := make(map[string]interface{})
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || field.Name == "Raw" {
+				continue
+			}
+			// Flatten embedded structs, such as the abigen event of a parsed Teleporter event.
+			if field.Anonymous {
+				if embedded, ok := toOutputValue(v.Field(i)).(map[string]interface{}); ok {
+					for key, value := range embedded {
+						fields[key] = value
+					}
+					continue
+				}
+			}
+			fields[lowerFirst(field.Name)] = toOutputValue(v.Field(i))
+		}
+		return fields
+	case reflect.Array, reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return hexString(b)
+		}
+		elems := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elems[i] = toOutputValue(v.Index(i))
+		}
+		return elems
+	default:
+		return v.Interface()
+	}
+}
+
+// toJSONOutputValue converts the JSON encoding of v into its generic representation.
+func toJSONOutputValue(v json.Marshaler) (interface{}, error) {
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
+
+// hexString returns the 0x prefixed hex encoding of b.
+func hexString(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
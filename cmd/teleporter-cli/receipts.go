@@ -0,0 +1,205 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterClient "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	receiptsSourceBlockchainIDArg string
+	receiptsMessageIDsArg         []string
+	receiptsFeeTokenAddressArg    string
+	receiptsFeeAmountArg          string
+	receiptsAllowedRelayersArg    []string
+
+	errNoReceiptMessageIDs = errors.New("at least one message ID must be provided")
+)
+
+var receiptsCmd = &cobra.Command{
+	Use:   "receipts --rpc RPC_URL --teleporter-address CONTRACT_ADDRESS --source-blockchain-id BLOCKCHAIN_ID",
+	Short: "Lists the outstanding receipts for a source blockchain",
+	Long: `Given a source blockchain ID, this command lists the receipts in the receipt
+queue of the connected chain for messages received from the source blockchain.
+Each receipt's message nonce, relayer reward address and the ID of the message
+it corresponds to are shown. The receipts are sent back to the source
+blockchain with the next message sent to it, or with the send subcommand.`,
+	Args: cobra.NoArgs,
+	RunE: receiptsRunE,
+}
+
+var receiptsSendCmd = &cobra.Command{
+	Use: "send --rpc RPC_URL --teleporter-address CONTRACT_ADDRESS --source-blockchain-id BLOCKCHAIN_ID " +
+		"--message-ids MESSAGE_ID[,MESSAGE_ID...]",
+	Short: "Sends the receipts of the given messages to their source blockchain",
+	Long: `Given a source blockchain ID and the IDs of messages received from it, this
+command submits sendSpecifiedReceipts to send the receipts of the messages back
+to the source blockchain in a new message, so that the relayers of the messages
+can redeem their rewards without waiting for a message to be sent to the source
+blockchain. If a non-zero fee is provided, the sender must have already approved
+the Teleporter contract to spend the fee amount of the fee token.`,
+	Args: cobra.NoArgs,
+	RunE: receiptsSendRunE,
+}
+
+// receiptQueueOutput is the output schema of the receipts command.
+type receiptQueueOutput struct {
+	SourceBlockchainID string          `json:"sourceBlockchainID" yaml:"sourceBlockchainID"`
+	Size               uint64          `json:"size" yaml:"size"`
+	Receipts           []receiptOutput `json:"receipts" yaml:"receipts"`
+}
+
+type receiptOutput struct {
+	Index                uint64 `json:"index" yaml:"index"`
+	ReceivedMessageNonce string `json:"receivedMessageNonce" yaml:"receivedMessageNonce"`
+	RelayerRewardAddress string `json:"relayerRewardAddress" yaml:"relayerRewardAddress"`
+	MessageID            string `json:"messageID" yaml:"messageID"`
+}
+
+func receiptsRunE(cmd *cobra.Command, args []string) error {
+	sourceBlockchainID, err := parseID(receiptsSourceBlockchainIDArg)
+	if err != nil {
+		return err
+	}
+
+	messenger, err := teleportermessenger.NewTeleporterMessengerCaller(teleporterAddress, client)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: context.Background()}
+	blockchainID, err := messenger.BlockchainID(opts)
+	if err != nil {
+		return err
+	}
+	size, err := messenger.GetReceiptQueueSize(opts, sourceBlockchainID)
+	if err != nil {
+		return err
+	}
+
+	out := receiptQueueOutput{
+		SourceBlockchainID: sourceBlockchainID.String(),
+		Size:               size.Uint64(),
+		Receipts:           make([]receiptOutput, 0, size.Uint64()),
+	}
+	for i := uint64(0); i < size.Uint64(); i++ {
+		receipt, err := messenger.GetReceiptAtIndex(opts, sourceBlockchainID, new(big.Int).SetUint64(i))
+		if err != nil {
+			return err
+		}
+		// Receipts are for messages sent from the source blockchain to this blockchain. Their IDs are
+		// computed offline, as calculateMessageID does with the address of the contract it is called on.
+		messageID, err := teleportermessenger.CalculateMessageID(
+			teleporterAddress, sourceBlockchainID, ids.ID(blockchainID), receipt.ReceivedMessageNonce,
+		)
+		if err != nil {
+			return err
+		}
+		out.Receipts = append(out.Receipts, receiptOutput{
+			Index:                i,
+			ReceivedMessageNonce: receipt.ReceivedMessageNonce.String(),
+			RelayerRewardAddress: receipt.RelayerRewardAddress.Hex(),
+			MessageID:            common.Hash(messageID).Hex(),
+		})
+	}
+
+	if err := printOutput(cmd, out); err != nil {
+		return err
+	}
+	cmd.Println("Receipts command ran successfully")
+	return nil
+}
+
+func receiptsSendRunE(cmd *cobra.Command, args []string) error {
+	sourceBlockchainID, err := parseID(receiptsSourceBlockchainIDArg)
+	if err != nil {
+		return err
+	}
+	if len(receiptsMessageIDsArg) == 0 {
+		return errNoReceiptMessageIDs
+	}
+	messageIDs := make([][32]byte, 0, len(receiptsMessageIDsArg))
+	for _, arg := range receiptsMessageIDsArg {
+		messageID, err := parseID(arg)
+		if err != nil {
+			return err
+		}
+		messageIDs = append(messageIDs, messageID)
+	}
+	feeInfo, err := parseFeeInfo(receiptsFeeTokenAddressArg, receiptsFeeAmountArg)
+	if err != nil {
+		return err
+	}
+	allowedRelayers, err := parseAddresses(receiptsAllowedRelayersArg)
+	if err != nil {
+		return err
+	}
+
+	signer, err := loadSigner(context.Background())
+	if err != nil {
+		return err
+	}
+
+	data, err := teleportermessenger.PackSendSpecifiedReceipts(sourceBlockchainID, messageIDs, feeInfo, allowedRelayers)
+	if err != nil {
+		return err
+	}
+	if dryRunArg {
+		return simulateAndPrintTransaction(context.Background(), cmd, client, signer, teleporterAddress, data, 0)
+	}
+	receipt, err := createAndSendTransaction(context.Background(), client, signer, teleporterAddress, data, 0)
+	if err != nil {
+		return err
+	}
+
+	messenger, err := teleportermessenger.NewTeleporterMessenger(teleporterAddress, client)
+	if err != nil {
+		return err
+	}
+	event, err := teleporterClient.EventFromLogs(receipt.Logs, teleporterAddress, messenger.ParseSendCrossChainMessage)
+	if err != nil {
+		return err
+	}
+
+	err = printOutput(cmd, sendOutput{
+		TxHash:       receipt.TxHash.Hex(),
+		MessageID:    common.Hash(event.MessageID).Hex(),
+		MessageNonce: event.Message.MessageNonce.String(),
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Println("Receipts send command ran successfully")
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(receiptsCmd)
+	receiptsCmd.AddCommand(receiptsSendCmd)
+	addClientFlags(receiptsCmd)
+	addKeyFlags(receiptsSendCmd)
+	receiptsCmd.PersistentFlags().StringVar(&receiptsSourceBlockchainIDArg, "source-blockchain-id", "",
+		"Blockchain ID that the receipts are sent to, CB58 or hex encoded")
+	err := receiptsCmd.MarkPersistentFlagRequired("source-blockchain-id")
+	cobra.CheckErr(err)
+
+	receiptsSendCmd.Flags().StringSliceVar(&receiptsMessageIDsArg, "message-ids", []string{},
+		"IDs of the received messages to send receipts for, CB58 or hex encoded")
+	receiptsSendCmd.Flags().StringVar(&receiptsFeeTokenAddressArg, "fee-token-address", "",
+		"ERC20 contract address of the relayer fee")
+	receiptsSendCmd.Flags().StringVar(&receiptsFeeAmountArg, "fee-amount", "0",
+		"Relayer fee amount, in the fee token's smallest unit")
+	receiptsSendCmd.Flags().StringSliceVar(&receiptsAllowedRelayersArg, "allowed-relayers", []string{},
+		"Addresses allowed to deliver the receipts. Any relayer may deliver them if empty")
+	err = receiptsSendCmd.MarkFlagRequired("message-ids")
+	cobra.CheckErr(err)
+}
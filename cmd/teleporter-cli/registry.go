@@ -0,0 +1,451 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleporterClient "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/client"
+	teleporterregistry "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/upgrades/TeleporterRegistry"
+	gasUtils "github.com/ava-labs/teleporter/utils/gas-utils"
+	teleporterUtils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	// registryVersionNotFound is the revert reason of getAddressFromVersion for unregistered versions.
+	registryVersionNotFound = "TeleporterRegistry: version not found"
+
+	// addProtocolVersionGasLimit is the gas used by addProtocolVersion beyond the verification of
+	// its Warp message, which is accounted for by gasUtils.CalculateReceiveMessageGasLimit.
+	addProtocolVersionGasLimit = 200_000
+)
+
+var (
+	registryAddress common.Address
+
+	registryFromBlockArg   uint64
+	registryToBlockArg     uint64
+	registryChunkSizeArg   uint64
+	registryWarpMessageArg string
+
+	errInvalidRegistrySourceAddress = errors.New("warp message is not an off-chain message from the validators")
+	errInvalidRegistrySourceChain   = errors.New("warp message's source chain is not the registry's blockchain")
+	errInvalidRegistryDestination   = errors.New("warp message is not addressed to the registry")
+	errNoRegisteredVersions         = errors.New("no protocol versions are registered in the registry")
+)
+
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Inspects and updates a TeleporterRegistry",
+	Long: `The registry subcommands read the protocol versions registered in a
+TeleporterRegistry contract, decode its history, and register new protocol
+versions from signed off-chain Warp messages.`,
+}
+
+var registryListCmd = &cobra.Command{
+	Use:   "list --rpc RPC_URL --registry-address CONTRACT_ADDRESS",
+	Short: "Lists the registered protocol versions",
+	Long: `Lists the protocol address of each version registered in the TeleporterRegistry,
+from version 1 to the latest version. Versions that were skipped when
+registering a later version are omitted.`,
+	Args: cobra.NoArgs,
+	RunE: registryListRunE,
+}
+
+var registryLatestCmd = &cobra.Command{
+	Use:   "latest --rpc RPC_URL --registry-address CONTRACT_ADDRESS",
+	Short: "Shows the latest protocol version",
+	Long:  `Shows the latest protocol version registered in the TeleporterRegistry and its protocol address.`,
+	Args:  cobra.NoArgs,
+	RunE:  registryLatestRunE,
+}
+
+var registryResolveCmd = &cobra.Command{
+	Use:   "resolve --rpc RPC_URL --registry-address CONTRACT_ADDRESS (VERSION | PROTOCOL_ADDRESS)",
+	Short: "Resolves a protocol version to its address, or an address to its version",
+	Long: `Given a protocol version, this command resolves its protocol address with
+getAddressFromVersion. Given a hex encoded protocol address, it resolves the
+address' highest registered version with getVersionFromAddress.`,
+	Args: cobra.ExactArgs(1),
+	RunE: registryResolveRunE,
+}
+
+var registryHistoryCmd = &cobra.Command{
+	Use:   "history --rpc RPC_URL --registry-address CONTRACT_ADDRESS [--from-block BLOCK] [--to-block BLOCK]",
+	Short: "Decodes the registry's version history",
+	Long: `Decodes the AddProtocolVersion and LatestVersionUpdated logs emitted by the
+TeleporterRegistry in a range of blocks, in the order they were emitted. The
+range is queried in chunks of blocks to respect RPC range limits.`,
+	Args: cobra.NoArgs,
+	RunE: registryHistoryRunE,
+}
+
+var registryAddVersionCmd = &cobra.Command{
+	Use:   "add-version --rpc RPC_URL --registry-address CONTRACT_ADDRESS --warp-message HEX",
+	Short: "Registers a protocol version from a signed off-chain Warp message",
+	Long: `Given a signed off-chain Warp message registering a new protocol version, this
+command checks that the message was sent by the validators of the registry's
+blockchain and is addressed to the registry, then submits addProtocolVersion
+with the message included as a predicate in the transaction's access list. The
+registered version is read from the AddProtocolVersion log of the transaction.`,
+	Args: cobra.NoArgs,
+	RunE: registryAddVersionRunE,
+}
+
+// registryVersionOutput is the output schema of a registered protocol version.
+type registryVersionOutput struct {
+	Version         string `json:"version" yaml:"version"`
+	ProtocolAddress string `json:"protocolAddress" yaml:"protocolAddress"`
+}
+
+// registryListOutput is the output schema of the registry list command.
+type registryListOutput struct {
+	BlockchainID  string                  `json:"blockchainID" yaml:"blockchainID"`
+	LatestVersion string                  `json:"latestVersion" yaml:"latestVersion"`
+	Versions      []registryVersionOutput `json:"versions" yaml:"versions"`
+}
+
+// registryHistoryOutput is the output schema of the registry history command.
+type registryHistoryOutput struct {
+	FromBlock uint64      `json:"fromBlock" yaml:"fromBlock"`
+	ToBlock   uint64      `json:"toBlock" yaml:"toBlock"`
+	Logs      []logOutput `json:"logs" yaml:"logs"`
+}
+
+// registryAddVersionOutput is the output schema of the registry add-version command.
+type registryAddVersionOutput struct {
+	TxHash          string `json:"txHash" yaml:"txHash"`
+	Version         string `json:"version" yaml:"version"`
+	ProtocolAddress string `json:"protocolAddress" yaml:"protocolAddress"`
+	LatestVersion   string `json:"latestVersion" yaml:"latestVersion"`
+}
+
+func registryListRunE(cmd *cobra.Command, args []string) error {
+	registry, err := teleporterregistry.NewTeleporterRegistryCaller(registryAddress, client)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: context.Background()}
+	blockchainID, err := registry.BlockchainID(opts)
+	if err != nil {
+		return err
+	}
+	latestVersion, err := registry.LatestVersion(opts)
+	if err != nil {
+		return err
+	}
+
+	out := registryListOutput{
+		BlockchainID:  ids.ID(blockchainID).String(),
+		LatestVersion: latestVersion.String(),
+		Versions:      []registryVersionOutput{},
+	}
+	for version := big.NewInt(1); version.Cmp(latestVersion) <= 0; version = new(big.Int).Add(version, common.Big1) {
+		protocolAddress, err := registry.GetAddressFromVersion(opts, version)
+		if isRegistryVersionNotFound(err) {
+			logger.Debug("Skipping unregistered version", zap.Stringer("version", version))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		out.Versions = append(out.Versions, registryVersionOutput{
+			Version:         version.String(),
+			ProtocolAddress: protocolAddress.Hex(),
+		})
+	}
+
+	if err := printOutput(cmd, out); err != nil {
+		return err
+	}
+	cmd.Println("Registry list command ran successfully")
+	return nil
+}
+
+// isRegistryVersionNotFound reports whether err is the revert of getAddressFromVersion for an
+// unregistered version. Other errors that merely contain the revert reason are not.
+func isRegistryVersionNotFound(err error) bool {
+	reason, ok := teleporterClient.RevertReason(err)
+	return ok && reason == registryVersionNotFound
+}
+
+func registryLatestRunE(cmd *cobra.Command, args []string) error {
+	registry, err := teleporterregistry.NewTeleporterRegistryCaller(registryAddress, client)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: context.Background()}
+	latestVersion, err := registry.LatestVersion(opts)
+	if err != nil {
+		return err
+	}
+	// getAddressFromVersion reverts for version 0, which is the latest version of an empty registry.
+	if latestVersion.Sign() == 0 {
+		return errNoRegisteredVersions
+	}
+	protocolAddress, err := registry.GetAddressFromVersion(opts, latestVersion)
+	if err != nil {
+		return err
+	}
+
+	err = printOutput(cmd, registryVersionOutput{
+		Version:         latestVersion.String(),
+		ProtocolAddress: protocolAddress.Hex(),
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Println("Registry latest command ran successfully")
+	return nil
+}
+
+func registryResolveRunE(cmd *cobra.Command, args []string) error {
+	registry, err := teleporterregistry.NewTeleporterRegistryCaller(registryAddress, client)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: context.Background()}
+
+	var out registryVersionOutput
+	if common.IsHexAddress(args[0]) {
+		protocolAddress := common.HexToAddress(args[0])
+		version, err := registry.GetVersionFromAddress(opts, protocolAddress)
+		if err != nil {
+			return err
+		}
+		out = registryVersionOutput{Version: version.String(), ProtocolAddress: protocolAddress.Hex()}
+	} else {
+		version, err := parseUint256(args[0])
+		if err != nil {
+			return err
+		}
+		protocolAddress, err := registry.GetAddressFromVersion(opts, version)
+		if err != nil {
+			return err
+		}
+		out = registryVersionOutput{Version: version.String(), ProtocolAddress: protocolAddress.Hex()}
+	}
+
+	if err := printOutput(cmd, out); err != nil {
+		return err
+	}
+	cmd.Println("Registry resolve command ran successfully")
+	return nil
+}
+
+func registryHistoryRunE(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	toBlock := registryToBlockArg
+	if toBlock == 0 {
+		latest, err := client.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		toBlock = latest
+	}
+
+	registryABI, err := teleporterregistry.TeleporterRegistryMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	addProtocolVersionEvent := registryABI.Events["AddProtocolVersion"]
+	latestVersionUpdatedEvent := registryABI.Events["LatestVersionUpdated"]
+	logs, err := teleporterUtils.FilterLogs(ctx, client, interfaces.FilterQuery{
+		Addresses: []common.Address{registryAddress},
+		Topics:    [][]common.Hash{{addProtocolVersionEvent.ID, latestVersionUpdatedEvent.ID}},
+	}, registryFromBlockArg, toBlock, registryChunkSizeArg)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+
+	filterer, err := teleporterregistry.NewTeleporterRegistryFilterer(registryAddress, client)
+	if err != nil {
+		return err
+	}
+	out := registryHistoryOutput{
+		FromBlock: registryFromBlockArg,
+		ToBlock:   toBlock,
+		Logs:      make([]logOutput, 0, len(logs)),
+	}
+	for i := range logs {
+		log := &logs[i]
+		var (
+			name   string
+			parsed interface{}
+		)
+		switch log.Topics[0] {
+		case addProtocolVersionEvent.ID:
+			name = addProtocolVersionEvent.Name
+			parsed, err = filterer.ParseAddProtocolVersion(*log)
+		case latestVersionUpdatedEvent.ID:
+			name = latestVersionUpdatedEvent.Name
+			parsed, err = filterer.ParseLatestVersionUpdated(*log)
+		}
+		if err != nil {
+			return err
+		}
+		out.Logs = append(out.Logs, newLogOutput(log, name, toOutputFields(parsed)))
+	}
+
+	if err := printOutput(cmd, out); err != nil {
+		return err
+	}
+	cmd.Println("Registry history command ran successfully")
+	return nil
+}
+
+func registryAddVersionRunE(cmd *cobra.Command, args []string) error {
+	b, err := parseHexBytes(registryWarpMessageArg)
+	if err != nil {
+		return err
+	}
+	msg, err := parseSignedWarpMessage(b, false)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	signer, err := loadSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	registry, err := teleporterregistry.NewTeleporterRegistry(registryAddress, client)
+	if err != nil {
+		return err
+	}
+	blockchainID, err := registry.BlockchainID(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return err
+	}
+	entry, err := checkRegistryWarpMessage(msg, blockchainID, registryAddress)
+	if err != nil {
+		return err
+	}
+	logger.Info("Registering protocol version",
+		zap.Stringer("version", entry.Version),
+		zap.String("protocolAddress", entry.ProtocolAddress.Hex()))
+
+	numSigners, err := msg.Signature.NumSigners()
+	if err != nil {
+		return err
+	}
+	gasLimit, err := gasUtils.CalculateReceiveMessageGasLimit(numSigners, big.NewInt(addProtocolVersionGasLimit))
+	if err != nil {
+		return err
+	}
+	data, err := teleporterregistry.PackAddProtocolVersion(0)
+	if err != nil {
+		return err
+	}
+	tx, err := createPredicateTransaction(
+		ctx, client, signer.Address(), registryAddress, data, gasLimit, msg.Bytes(),
+	)
+	if err != nil {
+		return err
+	}
+	if dryRunArg {
+		// Warp predicates are only verified when a transaction is included in a block.
+		out, err := unsimulatedTransaction(ctx, signer, tx,
+			"not simulated, since eth_call does not verify Warp message predicates")
+		if err != nil {
+			return err
+		}
+		return printDryRun(cmd, out)
+	}
+	signedTx, err := signer.SignTx(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	receipt, err := sendTransaction(ctx, client, signedTx)
+	if err != nil {
+		return err
+	}
+
+	event, err := teleporterClient.EventFromLogs(receipt.Logs, registryAddress, registry.ParseAddProtocolVersion)
+	if err != nil {
+		return err
+	}
+	latestVersion, err := registry.LatestVersion(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return err
+	}
+
+	err = printOutput(cmd, registryAddVersionOutput{
+		TxHash:          receipt.TxHash.Hex(),
+		Version:         event.Version.String(),
+		ProtocolAddress: event.ProtocolAddress.Hex(),
+		LatestVersion:   latestVersion.String(),
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Println("Registry add-version command ran successfully")
+	return nil
+}
+
+// checkRegistryWarpMessage checks that the Warp message would be accepted by addProtocolVersion of
+// the registry at the given address, so that an invalid message is not submitted. Returns the
+// protocol registry entry of the message.
+func checkRegistryWarpMessage(
+	msg *avalancheWarp.Message,
+	blockchainID ids.ID,
+	address common.Address,
+) (*teleporterregistry.ProtocolRegistryEntry, error) {
+	if msg.UnsignedMessage.SourceChainID != blockchainID {
+		return nil, errInvalidRegistrySourceChain
+	}
+	addressedCall, err := warpPayload.ParseAddressedCall(msg.UnsignedMessage.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if common.BytesToAddress(addressedCall.SourceAddress) != (common.Address{}) {
+		return nil, errInvalidRegistrySourceAddress
+	}
+	entry, destinationAddress, err := teleporterregistry.UnpackProtocolRegistryEntryPayload(addressedCall.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack protocol registry entry: %w", err)
+	}
+	if destinationAddress != address {
+		return nil, errInvalidRegistryDestination
+	}
+	return entry, nil
+}
+
+func init() {
+	rootCmd.AddCommand(registryCmd)
+	registryCmd.AddCommand(registryListCmd, registryLatestCmd, registryResolveCmd, registryHistoryCmd,
+		registryAddVersionCmd)
+	addContractClientFlags(registryCmd, "registry-address", "r", "TeleporterRegistry contract address",
+		&registryAddress)
+
+	registryHistoryCmd.Flags().Uint64Var(&registryFromBlockArg, "from-block", 0, "First block of the range")
+	registryHistoryCmd.Flags().Uint64Var(&registryToBlockArg, "to-block", 0,
+		"Last block of the range. Defaults to the latest block")
+	registryHistoryCmd.Flags().Uint64Var(&registryChunkSizeArg, "chunk-size", teleporterUtils.DefaultFilterLogsChunkSize,
+		"Maximum number of blocks queried per eth_getLogs request")
+
+	addKeyFlags(registryAddVersionCmd)
+	registryAddVersionCmd.Flags().StringVar(&registryWarpMessageArg, "warp-message", "",
+		"Hex encoded signed off-chain Warp message registering the protocol version")
+	err := registryAddVersionCmd.MarkFlagRequired("warp-message")
+	cobra.CheckErr(err)
+}
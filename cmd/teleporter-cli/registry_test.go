@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleporterregistry "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/upgrades/TeleporterRegistry"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"registry", "list"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"registry", "list", "--help"},
+			err:  nil,
+			out:  "Lists the protocol address of each version registered in the TeleporterRegistry",
+		},
+		{
+			name: "add-version no args",
+			args: []string{"registry", "add-version"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+// fakeEmptyRegistryClient is an ethclient.Client whose calls are answered by a TeleporterRegistry with
+// no registered versions.
+type fakeEmptyRegistryClient struct {
+	ethClient
+}
+
+func (c *fakeEmptyRegistryClient) CallContract(
+	_ context.Context,
+	msg interfaces.CallMsg,
+	_ *big.Int,
+) ([]byte, error) {
+	registryABI, err := teleporterregistry.TeleporterRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	method, err := registryABI.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	if method.Name != "latestVersion" {
+		return nil, errors.New("execution reverted: TeleporterRegistry: zero version")
+	}
+	return method.Outputs.Pack(big.NewInt(0))
+}
+
+func TestRegistryLatestEmpty(t *testing.T) {
+	previousClient := client
+	t.Cleanup(func() {
+		client = previousClient
+	})
+	client = &fakeEmptyRegistryClient{}
+
+	err := registryLatestRunE(&cobra.Command{}, nil)
+	require.ErrorIs(t, err, errNoRegisteredVersions)
+}
+
+// testRPCError is an error returned by a node, such as the revert of a call.
+type testRPCError struct {
+	message string
+}
+
+func (e *testRPCError) Error() string  { return e.message }
+func (e *testRPCError) ErrorCode() int { return 3 }
+
+func TestIsRegistryVersionNotFound(t *testing.T) {
+	require.True(t, isRegistryVersionNotFound(&testRPCError{message: "execution reverted: " + registryVersionNotFound}))
+	require.True(t, isRegistryVersionNotFound(
+		fmt.Errorf("call failed: %w", &testRPCError{message: "execution reverted: " + registryVersionNotFound}),
+	))
+
+	// Errors that are not reverts with the reason, even if they contain it, are not missing versions.
+	require.False(t, isRegistryVersionNotFound(nil))
+	require.False(t, isRegistryVersionNotFound(errors.New("execution reverted: "+registryVersionNotFound)))
+	require.False(t, isRegistryVersionNotFound(&testRPCError{message: "proxy error: " + registryVersionNotFound}))
+	require.False(t, isRegistryVersionNotFound(
+		&testRPCError{message: "execution reverted: TeleporterRegistry: zero version"},
+	))
+}
+
+func TestCheckRegistryWarpMessage(t *testing.T) {
+	blockchainID := ids.GenerateTestID()
+	registry := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	entry := teleporterregistry.ProtocolRegistryEntry{
+		Version:         big.NewInt(2),
+		ProtocolAddress: common.HexToAddress("0x89abcdef0123456789abcdef0123456789abcdef"),
+	}
+
+	newMessage := func(sourceChainID ids.ID, sourceAddress, destination common.Address) *avalancheWarp.Message {
+		payload, err := teleporterregistry.PackProtocolRegistryEntryPayload(entry, destination)
+		require.NoError(t, err)
+		addressedCall, err := warpPayload.NewAddressedCall(sourceAddress.Bytes(), payload)
+		require.NoError(t, err)
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(1, sourceChainID, addressedCall.Bytes())
+		require.NoError(t, err)
+		msg, err := avalancheWarp.NewMessage(unsignedMsg, &avalancheWarp.BitSetSignature{})
+		require.NoError(t, err)
+		return msg
+	}
+
+	var tests = []struct {
+		name string
+		msg  *avalancheWarp.Message
+		err  error
+	}{
+		{
+			name: "valid",
+			msg:  newMessage(blockchainID, common.Address{}, registry),
+		},
+		{
+			name: "wrong source chain",
+			msg:  newMessage(ids.GenerateTestID(), common.Address{}, registry),
+			err:  errInvalidRegistrySourceChain,
+		},
+		{
+			name: "not off-chain",
+			msg:  newMessage(blockchainID, registry, registry),
+			err:  errInvalidRegistrySourceAddress,
+		},
+		{
+			name: "wrong destination",
+			msg:  newMessage(blockchainID, common.Address{}, entry.ProtocolAddress),
+			err:  errInvalidRegistryDestination,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unpacked, err := checkRegistryWarpMessage(tt.msg, blockchainID, registry)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, entry.Version, unpacked.Version)
+			require.Equal(t, entry.ProtocolAddress, unpacked.ProtocolAddress)
+		})
+	}
+}
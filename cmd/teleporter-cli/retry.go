@@ -0,0 +1,218 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	gasUtils "github.com/ava-labs/teleporter/utils/gas-utils"
+	teleporterUtils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	retryMessageIDArg      string
+	retryLookBackBlocksArg uint64
+
+	errNoFailedExecution   = errors.New("message has no failed execution to retry, it may have already been executed")
+	errNoSentMessage       = errors.New("message has no sent message hash, its receipt may have already been received")
+	errMessageHashMismatch = errors.New("hash of the message found in the logs does not match the stored message hash")
+)
+
+var retryExecutionCmd = &cobra.Command{
+	Use:   "retry-execution --rpc RPC_URL --teleporter-address CONTRACT_ADDRESS --message-id MESSAGE_ID",
+	Short: "Retries the execution of a message that failed to execute",
+	Long: `Given the ID of a message whose execution failed on the connected chain, this
+command finds the message in its MessageExecutionFailed log, verifies it against
+the message hash stored by the Teleporter contract and submits
+retryMessageExecution with it. The gas limit of the transaction is at least that
+of a delivery of the message, so that the receiver is provided at least the
+message's required gas limit.`,
+	Args: cobra.NoArgs,
+	RunE: retryExecutionRunE,
+}
+
+var retrySendCmd = &cobra.Command{
+	Use:   "retry-send --rpc RPC_URL --teleporter-address CONTRACT_ADDRESS --message-id MESSAGE_ID",
+	Short: "Resends a message that has not been delivered",
+	Long: `Given the ID of a message sent from the connected chain whose receipt has not
+been received, this command finds the message in its SendCrossChainMessage log,
+verifies it against the message hash returned by getMessageHash and submits
+retrySendCrossChainMessage with it, so that the message's Warp message is sent
+again for relayers to deliver.`,
+	Args: cobra.NoArgs,
+	RunE: retrySendRunE,
+}
+
+// retryOutput is the output schema of the retry-execution and retry-send commands.
+type retryOutput struct {
+	TxHash      string `json:"txHash" yaml:"txHash"`
+	MessageID   string `json:"messageID" yaml:"messageID"`
+	MessageHash string `json:"messageHash" yaml:"messageHash"`
+	GasUsed     uint64 `json:"gasUsed" yaml:"gasUsed"`
+}
+
+func retryExecutionRunE(cmd *cobra.Command, args []string) error {
+	messageID, err := parseID(retryMessageIDArg)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	signer, err := loadSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	fromBlock, _, err := teleporterUtils.LookBackStartBlock(ctx, client, retryLookBackBlocksArg)
+	if err != nil {
+		return err
+	}
+	event, err := teleporterUtils.GetMessageExecutionFailedEvent(ctx, client, teleporterAddress, messageID, fromBlock)
+	if err != nil {
+		return err
+	}
+
+	messenger, err := teleportermessenger.NewTeleporterMessengerCaller(teleporterAddress, client)
+	if err != nil {
+		return err
+	}
+	storedHash, err := messenger.ReceivedFailedMessageHashes(&bind.CallOpts{Context: ctx}, messageID)
+	if err != nil {
+		return err
+	}
+	if storedHash == [32]byte{} {
+		return errNoFailedExecution
+	}
+	messageHash, err := verifyMessageHash(event.Message, storedHash)
+	if err != nil {
+		return err
+	}
+
+	gasLimit, err := retryExecutionGasLimit(event.Message)
+	if err != nil {
+		return err
+	}
+	data, err := teleportermessenger.PackRetryMessageExecution(event.SourceBlockchainID, event.Message)
+	if err != nil {
+		return err
+	}
+	if dryRunArg {
+		return simulateAndPrintTransaction(ctx, cmd, client, signer, teleporterAddress, data, gasLimit)
+	}
+	receipt, err := createAndSendTransaction(ctx, client, signer, teleporterAddress, data, gasLimit)
+	if err != nil {
+		return err
+	}
+
+	err = printOutput(cmd, retryOutput{
+		TxHash:      receipt.TxHash.Hex(),
+		MessageID:   common.Hash(messageID).Hex(),
+		MessageHash: messageHash.Hex(),
+		GasUsed:     receipt.GasUsed,
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Println("Retry execution command ran successfully")
+	return nil
+}
+
+func retrySendRunE(cmd *cobra.Command, args []string) error {
+	messageID, err := parseID(retryMessageIDArg)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	signer, err := loadSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	fromBlock, _, err := teleporterUtils.LookBackStartBlock(ctx, client, retryLookBackBlocksArg)
+	if err != nil {
+		return err
+	}
+	event, err := teleporterUtils.GetSendCrossChainMessageEventByID(ctx, client, teleporterAddress, messageID, fromBlock)
+	if err != nil {
+		return err
+	}
+
+	messenger, err := teleportermessenger.NewTeleporterMessengerCaller(teleporterAddress, client)
+	if err != nil {
+		return err
+	}
+	storedHash, err := messenger.GetMessageHash(&bind.CallOpts{Context: ctx}, messageID)
+	if err != nil {
+		return err
+	}
+	if storedHash == [32]byte{} {
+		return errNoSentMessage
+	}
+	messageHash, err := verifyMessageHash(event.Message, storedHash)
+	if err != nil {
+		return err
+	}
+
+	data, err := teleportermessenger.PackRetrySendCrossChainMessage(event.Message)
+	if err != nil {
+		return err
+	}
+	if dryRunArg {
+		return simulateAndPrintTransaction(ctx, cmd, client, signer, teleporterAddress, data, 0)
+	}
+	receipt, err := createAndSendTransaction(ctx, client, signer, teleporterAddress, data, 0)
+	if err != nil {
+		return err
+	}
+
+	err = printOutput(cmd, retryOutput{
+		TxHash:      receipt.TxHash.Hex(),
+		MessageID:   common.Hash(messageID).Hex(),
+		MessageHash: messageHash.Hex(),
+		GasUsed:     receipt.GasUsed,
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Println("Retry send command ran successfully")
+	return nil
+}
+
+// verifyMessageHash returns the hash of the ABI encoded message, or an error if it does not match
+// the hash stored by the Teleporter contract.
+func verifyMessageHash(message teleportermessenger.TeleporterMessage, storedHash [32]byte) (common.Hash, error) {
+	messageHash, err := teleportermessenger.CalculateMessageHash(message)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if messageHash != common.Hash(storedHash) {
+		return common.Hash{}, errMessageHashMismatch
+	}
+	return messageHash, nil
+}
+
+// retryExecutionGasLimit returns the gas limit of a retryMessageExecution transaction for message.
+// No signatures are verified when retrying, but the retry must provide at least the message's
+// required gas limit to the receiver, so it is given the gas limit of a delivery of the message
+// without signers.
+func retryExecutionGasLimit(message teleportermessenger.TeleporterMessage) (uint64, error) {
+	return gasUtils.CalculateReceiveMessageGasLimit(0, message.RequiredGasLimit)
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{retryExecutionCmd, retrySendCmd} {
+		rootCmd.AddCommand(cmd)
+		addClientFlags(cmd)
+		addKeyFlags(cmd)
+		cmd.Flags().StringVar(&retryMessageIDArg, "message-id", "", "ID of the message, CB58 or hex encoded")
+		cmd.Flags().Uint64Var(&retryLookBackBlocksArg, "look-back-blocks", teleporterUtils.DefaultLookBackBlocks,
+			"Number of recent blocks to search for the message's log")
+		err := cmd.MarkFlagRequired("message-id")
+		cobra.CheckErr(err)
+	}
+}
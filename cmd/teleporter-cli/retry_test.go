@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	gasUtils "github.com/ava-labs/teleporter/utils/gas-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryExecutionCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"retry-execution"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"retry-execution", "--help"},
+			err:  nil,
+			out:  "Given the ID of a message whose execution failed on the connected chain, this",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestRetrySendCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"retry-send"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"retry-send", "--help"},
+			err:  nil,
+			out:  "Given the ID of a message sent from the connected chain whose receipt has not",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func newTestRetryMessage(requiredGasLimit *big.Int) teleportermessenger.TeleporterMessage {
+	return teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		OriginSenderAddress:     common.HexToAddress("0x1"),
+		DestinationBlockchainID: common.HexToHash("0x2"),
+		DestinationAddress:      common.HexToAddress("0x3"),
+		RequiredGasLimit:        requiredGasLimit,
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 []byte{1, 2},
+	}
+}
+
+func TestVerifyMessageHash(t *testing.T) {
+	message := newTestRetryMessage(big.NewInt(100_000))
+	expected, err := teleportermessenger.CalculateMessageHash(message)
+	require.NoError(t, err)
+
+	messageHash, err := verifyMessageHash(message, expected)
+	require.NoError(t, err)
+	require.Equal(t, expected, messageHash)
+
+	// A message found in the logs that differs from the stored message is not retried.
+	altered := newTestRetryMessage(big.NewInt(100_001))
+	_, err = verifyMessageHash(altered, expected)
+	require.ErrorIs(t, err, errMessageHashMismatch)
+
+	_, err = verifyMessageHash(message, [32]byte{})
+	require.ErrorIs(t, err, errMessageHashMismatch)
+}
+
+func TestRetryExecutionGasLimit(t *testing.T) {
+	// The retry is given the gas limit of a delivery without signers, which is at least the
+	// required gas limit of the message plus the overhead of its execution.
+	for _, requiredGasLimit := range []int64{0, 100_000, 8_000_000} {
+		message := newTestRetryMessage(big.NewInt(requiredGasLimit))
+		gasLimit, err := retryExecutionGasLimit(message)
+		require.NoError(t, err)
+
+		expected, err := gasUtils.CalculateReceiveMessageGasLimit(0, message.RequiredGasLimit)
+		require.NoError(t, err)
+		require.Equal(t, expected, gasLimit)
+		require.Equal(t,
+			uint64(requiredGasLimit)+gasUtils.ReceiveCrossChainMessageStaticGasCost+
+				gasUtils.ReceiveMessageGasLimitBufferAmount,
+			gasLimit,
+		)
+	}
+
+	// Required gas limits that overflow the gas limit of a transaction are rejected.
+	_, err := retryExecutionGasLimit(newTestRetryMessage(new(big.Int).Lsh(big.NewInt(1), 64)))
+	require.Error(t, err)
+}
@@ -0,0 +1,106 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+)
+
+// revertExplanations maps the revert reasons of the Teleporter contracts to a description of their cause.
+var revertExplanations = map[string]string{
+	"TeleporterMessenger: insufficient gas": "The delivery transaction did not provide the message's required gas " +
+		"limit to the receiver. The relayer must resubmit the delivery with a higher gas limit.",
+	"TeleporterMessenger: invalid warp message": "The Warp message in the access list predicate was missing, " +
+		"or failed signature verification against the source subnet's validator set. Check the message's " +
+		"signers with the warp command and the relayer's aggregation.",
+	"TeleporterMessenger: invalid origin sender address": "The Warp message was not sent by the Teleporter " +
+		"contract at this address on the source chain, e.g. a different Teleporter version was used.",
+	"TeleporterMessenger: invalid destination chain ID": "The message is addressed to a different blockchain " +
+		"than the one it was delivered to.",
+	"TeleporterMessenger: message already received": "The message has already been delivered to this chain, " +
+		"typically by another relayer.",
+	"TeleporterMessenger: unauthorized relayer": "The delivering address is not in the message's allowed " +
+		"relayer addresses.",
+	"TeleporterMessenger: message not found": "No message with the given ID was found in the contract's state, " +
+		"e.g. it was never sent, its receipt was already received, or it did not fail execution.",
+	"TeleporterMessenger: invalid message hash": "The provided message does not match the hash stored when " +
+		"the message was sent or when its execution failed.",
+	"TeleporterMessenger: destination address has no code": "The message's destination address has no " +
+		"contract code, so its execution can't be retried until a contract is deployed there.",
+	"TeleporterMessenger: retry execution failed": "The receiver's receiveTeleporterMessage reverted or ran out " +
+		"of gas again when retrying the message's execution.",
+	"TeleporterMessenger: receipt not found": "No receipt for the given message ID was found for the source " +
+		"blockchain.",
+	"TeleporterMessenger: message ID not from source blockchain": "The message ID was not delivered from the " +
+		"given source blockchain.",
+	"TeleporterMessenger: no reward to redeem": "The caller has no relayer rewards to redeem for the given " +
+		"fee token.",
+	"TeleporterMessenger: message not received":       "The message has not been delivered to this chain.",
+	"TeleporterMessenger: zero blockchain ID":         "A zero blockchain ID was provided.",
+	"TeleporterMessenger: zero additional fee amount": "The additional fee amount must be non-zero.",
+	"TeleporterMessenger: zero fee asset contract address": "A non-zero fee amount was provided without a fee " +
+		"token address.",
+	"TeleporterMessenger: invalid fee asset contract address": "The additional fee must be paid in the same " +
+		"token as the message's original fee.",
+	"TeleporterMessenger: zero message nonce": "The message nonce must be non-zero.",
+	"ReentrancyGuards: sender reentrancy": "A sending function of the Teleporter contract was called " +
+		"reentrantly, e.g. by a fee token or receiver contract.",
+	"ReentrancyGuards: receiver reentrancy": "A receiving function of the Teleporter contract was called " +
+		"reentrantly, e.g. by a receiver contract.",
+	"ReceiptQueue: empty queue":         "The receipt queue is empty.",
+	"ReceiptQueue: index out of bounds": "The receipt queue index is out of bounds.",
+	"TeleporterUpgradeable: invalid Teleporter sender": "The message was delivered by a Teleporter contract " +
+		"that is not registered in the receiver's TeleporterRegistry.",
+	"TeleporterUpgradeable: invalid Teleporter version": "The message was delivered by a Teleporter version " +
+		"lower than the receiver's minimum Teleporter version, or the new minimum Teleporter version is higher " +
+		"than the registry's latest version.",
+	"TeleporterUpgradeable: Teleporter address paused": "The receiver has paused receiving messages from this " +
+		"Teleporter contract address.",
+	"TeleporterUpgradeable: Teleporter sending paused": "The contract has paused the latest Teleporter version, " +
+		"so it can't send messages until the address is unpaused or a new version is registered.",
+	"TeleporterUpgradeable: not greater than current minimum version": "The new minimum Teleporter version " +
+		"must be greater than the current minimum version.",
+	"TeleporterUpgradeable: zero Teleporter address": "The zero address can't be paused or unpaused.",
+	"TeleporterUpgradeable: address already paused":  "The Teleporter address is already paused.",
+	"TeleporterUpgradeable: address not paused":      "The Teleporter address is not paused.",
+	"TeleporterRegistry: invalid warp message": "The off-chain Warp message in the access list predicate was " +
+		"missing, or failed signature verification against the validator set of the registry's subnet.",
+	"TeleporterRegistry: invalid source chain ID": "The Warp message was not sent from the registry's " +
+		"blockchain.",
+	"TeleporterRegistry: invalid origin sender address": "The Warp message was not an off-chain message " +
+		"from the validators of the registry's blockchain.",
+	"TeleporterRegistry: invalid destination address": "The Warp message is addressed to a different registry.",
+	"TeleporterRegistry: zero version":                "Version zero is not a valid protocol version.",
+	"TeleporterRegistry: version already exists":      "The protocol version is already registered.",
+	"TeleporterRegistry: zero protocol address":       "The zero address is not a valid protocol address.",
+	"TeleporterRegistry: version increment too high": "The protocol version is more than MAX_VERSION_INCREMENT " +
+		"versions higher than the latest version.",
+	"TeleporterRegistry: version not found":          "The protocol version is not registered.",
+	"TeleporterRegistry: protocol address not found": "The protocol address is not registered.",
+}
+
+// explainRevert returns a description of the cause of the given revert reason. Reasons that are not
+// emitted by the Teleporter contracts are only quoted.
+func explainRevert(reason string) string {
+	if explanation, ok := revertExplanations[reason]; ok {
+		return explanation
+	}
+	if reason == "" {
+		return "The call reverted without a reason."
+	}
+	return fmt.Sprintf("The call reverted with reason %q.", reason)
+}
+
+// unpackRevertReason returns the reason of a revert from its output data, or an empty string if the
+// data is not an Error(string) revert.
+func unpackRevertReason(data []byte) string {
+	reason, err := abi.UnpackRevert(data)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(reason)
+}
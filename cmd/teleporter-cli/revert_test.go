@@ -0,0 +1,113 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// packRevert returns the output data of a revert with the given Error(string) reason.
+func packRevert(t *testing.T, reason string) []byte {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	b, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	return append(crypto.Keccak256([]byte("Error(string)"))[:4], b...)
+}
+
+func TestExplainRevert(t *testing.T) {
+	var tests = []struct {
+		name        string
+		reason      string
+		explanation string
+	}{
+		{
+			name:        "teleporter messenger",
+			reason:      "TeleporterMessenger: insufficient gas",
+			explanation: revertExplanations["TeleporterMessenger: insufficient gas"],
+		},
+		{
+			name:        "teleporter registry",
+			reason:      "TeleporterRegistry: version not found",
+			explanation: "The protocol version is not registered.",
+		},
+		{
+			name:        "no reason",
+			reason:      "",
+			explanation: "The call reverted without a reason.",
+		},
+		{
+			name:        "unknown reason",
+			reason:      "ERC20: transfer amount exceeds balance",
+			explanation: `The call reverted with reason "ERC20: transfer amount exceeds balance".`,
+		},
+		{
+			// Reasons are matched exactly.
+			name:        "unknown prefix",
+			reason:      "teleportermessenger: insufficient gas",
+			explanation: `The call reverted with reason "teleportermessenger: insufficient gas".`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.explanation, explainRevert(tt.reason))
+		})
+	}
+
+	// Every known reason has its own explanation.
+	for reason, explanation := range revertExplanations {
+		require.NotEmpty(t, explanation, reason)
+		require.Equal(t, explanation, explainRevert(reason))
+	}
+}
+
+func TestUnpackRevertReason(t *testing.T) {
+	reason := "TeleporterMessenger: message not found"
+	panicData := append(crypto.Keccak256([]byte("Panic(uint256)"))[:4], make([]byte, 32)...)
+
+	var tests = []struct {
+		name   string
+		data   []byte
+		reason string
+	}{
+		{
+			name:   "reason",
+			data:   packRevert(t, reason),
+			reason: reason,
+		},
+		{
+			name:   "surrounding whitespace",
+			data:   packRevert(t, " "+reason+"\n"),
+			reason: reason,
+		},
+		{
+			name:   "empty reason",
+			data:   packRevert(t, ""),
+			reason: "",
+		},
+		{
+			name:   "no data",
+			data:   nil,
+			reason: "",
+		},
+		{
+			name:   "panic",
+			data:   panicData,
+			reason: "",
+		},
+		{
+			name:   "truncated",
+			data:   packRevert(t, reason)[:36],
+			reason: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.reason, unpackRevertReason(tt.data))
+		})
+	}
+}
@@ -0,0 +1,378 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterUtils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rewardsRPCsArg      []string
+	rewardsAddressArg   string
+	relayerArg          string
+	feeTokensArg        []string
+	rewardsFromBlockArg uint64
+	rewardsChunkSizeArg uint64
+	redeemArg           bool
+	rewardsChainsArg    []string
+
+	errRedeemerIsNotRelayer   = errors.New("rewards can only be redeemed with the relayer's key")
+	errMissingRewardsFeeToken = errors.New("at least one fee token address must be provided")
+)
+
+var rewardsCmd = &cobra.Command{
+	Use: "rewards --rpc RPC_URL [--rpc RPC_URL...] --teleporter-address CONTRACT_ADDRESS --relayer ADDRESS " +
+		"--fee-tokens ADDRESS[,ADDRESS...] [--redeem]",
+	Short: "Reports the relayer rewards owed to a relayer",
+	Long: `Given a relayer address and a list of fee token addresses, this command reports
+the relayer's redeemable reward balance of each token on each chain by calling
+checkRelayerRewardAmount. It also reconstructs the relayer's reward history on
+each chain from the ReceiptReceived and RelayerRewardsRedeemed logs since
+--from-block, reporting the amounts earned, broken down by the destination
+blockchain of the delivered messages, and redeemed. Rewards are earned on the
+source chain of the delivered messages. If --redeem is set, redeemRelayerRewards
+is submitted for each token with a non-zero balance, signed with the relayer's
+key, or simulated and signed but not submitted if --dry-run is also set. The
+endpoints and Teleporter address can be read from the config file with --chain,
+which may be repeated. Each chain's Teleporter address and key are used on that
+chain, unless --teleporter-address or a key flag is set.`,
+	Args:    cobra.NoArgs,
+	PreRunE: rewardsPreRunE,
+	RunE:    rewardsRunE,
+}
+
+// rewardsOutput is the output schema of the rewards command.
+type rewardsOutput struct {
+	Relayer string               `json:"relayer" yaml:"relayer"`
+	Chains  []chainRewardsOutput `json:"chains" yaml:"chains"`
+}
+
+// chainRewardsOutput is the reward ledger of the relayer on a single chain.
+type chainRewardsOutput struct {
+	RPC          string               `json:"rpc" yaml:"rpc"`
+	BlockchainID string               `json:"blockchainID" yaml:"blockchainID"`
+	FromBlock    uint64               `json:"fromBlock" yaml:"fromBlock"`
+	ToBlock      uint64               `json:"toBlock" yaml:"toBlock"`
+	Tokens       []tokenRewardsOutput `json:"tokens" yaml:"tokens"`
+}
+
+// tokenRewardsOutput is the reward ledger of the relayer for a single fee token. Amounts are decimal
+// strings in the token's smallest unit, and EarnedByDestination is keyed by CB58 blockchain ID.
+type tokenRewardsOutput struct {
+	FeeToken            string            `json:"feeToken" yaml:"feeToken"`
+	Earned              string            `json:"earned" yaml:"earned"`
+	EarnedByDestination map[string]string `json:"earnedByDestination" yaml:"earnedByDestination"`
+	Redeemed            string            `json:"redeemed" yaml:"redeemed"`
+	Balance             string            `json:"balance" yaml:"balance"`
+	RedeemTxHash        string            `json:"redeemTxHash,omitempty" yaml:"redeemTxHash,omitempty"`
+	RedeemDryRun        *dryRunTxOutput   `json:"redeemDryRun,omitempty" yaml:"redeemDryRun,omitempty"`
+}
+
+// tokenLedger accumulates the rewards of a single fee token.
+type tokenLedger struct {
+	earned              *big.Int
+	earnedByDestination map[ids.ID]*big.Int
+	redeemed            *big.Int
+}
+
+func newTokenLedger() *tokenLedger {
+	return &tokenLedger{
+		earned:              new(big.Int),
+		earnedByDestination: make(map[ids.ID]*big.Int),
+		redeemed:            new(big.Int),
+	}
+}
+
+// addEarned records a reward earned for delivering a message to the given destination blockchain.
+func (l *tokenLedger) addEarned(destination ids.ID, amount *big.Int) {
+	l.earned.Add(l.earned, amount)
+	if l.earnedByDestination[destination] == nil {
+		l.earnedByDestination[destination] = new(big.Int)
+	}
+	l.earnedByDestination[destination].Add(l.earnedByDestination[destination], amount)
+}
+
+// addRedeemed records a redemption of rewards.
+func (l *tokenLedger) addRedeemed(amount *big.Int) {
+	l.redeemed.Add(l.redeemed, amount)
+}
+
+// output returns the ledger of the given fee token, along with the relayer's current reward balance.
+func (l *tokenLedger) output(token common.Address, balance *big.Int) tokenRewardsOutput {
+	out := tokenRewardsOutput{
+		FeeToken:            token.Hex(),
+		Earned:              l.earned.String(),
+		EarnedByDestination: make(map[string]string, len(l.earnedByDestination)),
+		Redeemed:            l.redeemed.String(),
+		Balance:             balance.String(),
+	}
+	for destination, amount := range l.earnedByDestination {
+		out.EarnedByDestination[destination.String()] = amount.String()
+	}
+	return out
+}
+
+// rewardsTarget is a chain to report rewards on, with the Teleporter contract and the signing key
+// used on it.
+type rewardsTarget struct {
+	rpc     string
+	address string
+	key     keySource
+}
+
+// rewardsChainTargets are the chains selected from the config file with --chain, or nil if the
+// chains are given by the --rpc and --teleporter-address flags.
+var rewardsChainTargets []rewardsTarget
+
+// rewardsPreRunE resolves the endpoint, Teleporter address and signing key of each chain selected from
+// the config file. An explicit --teleporter-address or key flag applies to every chain. If --rpc is set
+// explicitly, its endpoints are not paired with the chains, and the Teleporter address and signing
+// key are defaulted from the first chain. It runs before cobra checks for required flags.
+func rewardsPreRunE(cmd *cobra.Command, args []string) error {
+	rewardsChainTargets = nil
+	if len(rewardsChainsArg) == 0 {
+		return nil
+	}
+	if cmd.Flags().Changed("rpc") {
+		first, err := selectChain(rewardsChainsArg[0])
+		if err != nil {
+			return err
+		}
+		return setChainFlags(cmd, map[string]string{"teleporter-address": first.TeleporterAddress})
+	}
+
+	explicitAddress := cmd.Flags().Changed("teleporter-address")
+	targets := make([]rewardsTarget, 0, len(rewardsChainsArg))
+	rpcs := make([]string, 0, len(rewardsChainsArg))
+	for _, name := range rewardsChainsArg {
+		chain, err := selectChain(name)
+		if err != nil {
+			return err
+		}
+		if chain.RPCURL == "" {
+			return fmt.Errorf("chain %q has no RPC URL in the config file", name)
+		}
+		target := rewardsTarget{rpc: chain.RPCURL, address: rewardsAddressArg, key: configKey}
+		if !explicitAddress {
+			if chain.TeleporterAddress == "" {
+				return fmt.Errorf("chain %q has no Teleporter address in the config file", name)
+			}
+			target.address = chain.TeleporterAddress
+		}
+		targets = append(targets, target)
+		rpcs = append(rpcs, chain.RPCURL)
+	}
+	rewardsChainTargets = targets
+	// The flags are set so that the required flag checks pass, but the targets are used to run the command.
+	return setChainFlags(cmd, map[string]string{
+		"rpc":                strings.Join(rpcs, ","),
+		"teleporter-address": targets[0].address,
+	})
+}
+
+func rewardsRunE(cmd *cobra.Command, args []string) error {
+	relayer, err := parseAddress(relayerArg)
+	if err != nil {
+		return err
+	}
+	feeTokens, err := parseAddresses(feeTokensArg)
+	if err != nil {
+		return err
+	}
+	if len(feeTokens) == 0 {
+		return errMissingRewardsFeeToken
+	}
+
+	targets := rewardsChainTargets
+	if targets == nil {
+		for _, rpc := range rewardsRPCsArg {
+			targets = append(targets, rewardsTarget{rpc: rpc, address: rewardsAddressArg, key: configKey})
+		}
+	}
+	addresses := make([]common.Address, len(targets))
+	for i, target := range targets {
+		addresses[i], err = parseAddress(target.address)
+		if err != nil {
+			return err
+		}
+	}
+	// The signers are loaded before any rewards are redeemed, so that a key that is not the relayer's
+	// is rejected before the first chain is redeemed on.
+	signers := make([]txSigner, len(targets))
+	if redeemArg {
+		for i, target := range targets {
+			signers[i], err = loadSignerForKey(context.Background(), target.key)
+			if err != nil {
+				return err
+			}
+			if signers[i].Address() != relayer {
+				return errRedeemerIsNotRelayer
+			}
+		}
+	}
+
+	out := rewardsOutput{
+		Relayer: relayer.Hex(),
+		Chains:  make([]chainRewardsOutput, 0, len(targets)),
+	}
+	for i, target := range targets {
+		chainOut, err := chainRewards(context.Background(), target.rpc, addresses[i], relayer, feeTokens, signers[i])
+		if err != nil {
+			return fmt.Errorf("failed to get rewards from %s: %w", target.rpc, err)
+		}
+		out.Chains = append(out.Chains, *chainOut)
+	}
+
+	if err := printOutput(cmd, out); err != nil {
+		return err
+	}
+	cmd.Println("Rewards command ran successfully")
+	return nil
+}
+
+// chainRewards builds the relayer's reward ledger on the chain at the given RPC endpoint, redeeming
+// the rewards of each fee token with a non-zero balance if signer is non-nil.
+func chainRewards(
+	ctx context.Context,
+	rpc string,
+	address common.Address,
+	relayer common.Address,
+	feeTokens []common.Address,
+	signer txSigner,
+) (*chainRewardsOutput, error) {
+	c, err := ethclient.Dial(rpc)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	messenger, err := teleportermessenger.NewTeleporterMessenger(address, c)
+	if err != nil {
+		return nil, err
+	}
+	blockchainID, err := messenger.BlockchainID(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, err
+	}
+	latest, err := c.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgers := make(map[common.Address]*tokenLedger, len(feeTokens))
+	tokenTopics := make([]common.Hash, 0, len(feeTokens))
+	for _, token := range feeTokens {
+		ledgers[token] = newTokenLedger()
+		tokenTopics = append(tokenTopics, common.BytesToHash(token.Bytes()))
+	}
+	relayerTopic := []common.Hash{common.BytesToHash(relayer.Bytes())}
+
+	// The fee token of a receipt is not indexed, so receipts of other tokens are filtered out after parsing.
+	receiptLogs, err := teleporterUtils.FilterLogs(ctx, c, interfaces.FilterQuery{
+		Addresses: []common.Address{address},
+		Topics:    [][]common.Hash{{teleporterABI.Events["ReceiptReceived"].ID}, nil, nil, relayerTopic},
+	}, rewardsFromBlockArg, latest, rewardsChunkSizeArg)
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range receiptLogs {
+		event, err := messenger.ParseReceiptReceived(log)
+		if err != nil {
+			return nil, err
+		}
+		ledger, ok := ledgers[event.FeeInfo.FeeTokenAddress]
+		if !ok {
+			continue
+		}
+		ledger.addEarned(ids.ID(event.DestinationBlockchainID), event.FeeInfo.Amount)
+	}
+
+	redeemLogs, err := teleporterUtils.FilterLogs(ctx, c, interfaces.FilterQuery{
+		Addresses: []common.Address{address},
+		Topics:    [][]common.Hash{{teleporterABI.Events["RelayerRewardsRedeemed"].ID}, relayerTopic, tokenTopics},
+	}, rewardsFromBlockArg, latest, rewardsChunkSizeArg)
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range redeemLogs {
+		event, err := messenger.ParseRelayerRewardsRedeemed(log)
+		if err != nil {
+			return nil, err
+		}
+		ledgers[event.Asset].addRedeemed(event.Amount)
+	}
+
+	out := &chainRewardsOutput{
+		RPC:          rpc,
+		BlockchainID: ids.ID(blockchainID).String(),
+		FromBlock:    rewardsFromBlockArg,
+		ToBlock:      latest,
+		Tokens:       make([]tokenRewardsOutput, 0, len(feeTokens)),
+	}
+	for _, token := range feeTokens {
+		balance, err := messenger.CheckRelayerRewardAmount(&bind.CallOpts{Context: ctx}, relayer, token)
+		if err != nil {
+			return nil, err
+		}
+		tokenOut := ledgers[token].output(token, balance)
+
+		if signer != nil && balance.Sign() > 0 {
+			data, err := teleportermessenger.PackRedeemRelayerRewards(token)
+			if err != nil {
+				return nil, err
+			}
+			if dryRunArg {
+				tokenOut.RedeemDryRun, err = simulateTransaction(ctx, c, signer, address, data, 0)
+				if err != nil {
+					return nil, fmt.Errorf("failed to simulate redeeming %s rewards: %w", token.Hex(), err)
+				}
+				out.Tokens = append(out.Tokens, tokenOut)
+				continue
+			}
+			receipt, err := createAndSendTransaction(ctx, c, signer, address, data, 0)
+			if err != nil {
+				return nil, fmt.Errorf("failed to redeem %s rewards: %w", token.Hex(), err)
+			}
+			tokenOut.RedeemTxHash = receipt.TxHash.Hex()
+		}
+		out.Tokens = append(out.Tokens, tokenOut)
+	}
+	return out, nil
+}
+
+func init() {
+	rootCmd.AddCommand(rewardsCmd)
+	addKeyFlags(rewardsCmd)
+	rewardsCmd.Flags().StringSliceVar(&rewardsRPCsArg, "rpc", []string{},
+		"RPC endpoints of the chains to report rewards on. May be repeated")
+	rewardsCmd.Flags().StringVarP(&rewardsAddressArg, "teleporter-address", "t", "", "Teleporter contract address")
+	rewardsCmd.Flags().StringVar(&relayerArg, "relayer", "", "Address of the relayer that rewards are owed to")
+	rewardsCmd.Flags().StringSliceVar(&feeTokensArg, "fee-tokens", []string{}, "Fee token addresses to report")
+	rewardsCmd.Flags().Uint64Var(&rewardsFromBlockArg, "from-block", 0,
+		"First block to reconstruct the reward history from")
+	rewardsCmd.Flags().Uint64Var(&rewardsChunkSizeArg, "chunk-size", teleporterUtils.DefaultFilterLogsChunkSize,
+		"Maximum number of blocks queried per eth_getLogs request")
+	rewardsCmd.Flags().StringSliceVar(&rewardsChainsArg, "chain", []string{},
+		"Names of chains in the config file to report rewards on. May be repeated")
+	rewardsCmd.Flags().BoolVar(&redeemArg, "redeem", false,
+		"Submit redeemRelayerRewards for each fee token with a non-zero balance")
+
+	for _, flag := range []string{"rpc", "teleporter-address", "relayer", "fee-tokens"} {
+		err := rewardsCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+}
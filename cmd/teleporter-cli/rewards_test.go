@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewardsCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"rewards"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"rewards", "--help"},
+			err:  nil,
+			out:  "Given a relayer address and a list of fee token addresses, this command reports",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestTokenLedger(t *testing.T) {
+	token := common.HexToAddress("0x5DB9A7629912EBF95876228C24A848de0bfB43A9")
+	destinationA := ids.ID{1}
+	destinationB := ids.ID{2}
+	// Amounts beyond uint64 are accumulated without overflowing.
+	large := new(big.Int).Lsh(big.NewInt(1), 64)
+
+	// An empty ledger reports zero amounts.
+	ledger := newTokenLedger()
+	require.Equal(t, tokenRewardsOutput{
+		FeeToken:            token.Hex(),
+		Earned:              "0",
+		EarnedByDestination: map[string]string{},
+		Redeemed:            "0",
+		Balance:             "0",
+	}, ledger.output(token, big.NewInt(0)))
+
+	first := big.NewInt(10)
+	ledger.addEarned(destinationA, first)
+	ledger.addEarned(destinationB, big.NewInt(5))
+	ledger.addEarned(destinationA, large)
+	ledger.addRedeemed(big.NewInt(7))
+	ledger.addRedeemed(big.NewInt(3))
+	// The earned amounts are copied rather than accumulated into.
+	require.Equal(t, big.NewInt(10), first)
+
+	// The balance is reported as read from the contract, rather than derived from the ledger.
+	require.Equal(t, tokenRewardsOutput{
+		FeeToken: token.Hex(),
+		Earned:   "18446744073709551631",
+		EarnedByDestination: map[string]string{
+			destinationA.String(): "18446744073709551626",
+			destinationB.String(): "5",
+		},
+		Redeemed: "10",
+		Balance:  "18446744073709551621",
+	}, ledger.output(token, new(big.Int).Add(large, big.NewInt(5))))
+}
+
+func TestRewardsPreRunE(t *testing.T) {
+	previousConfigFile, previousConfigKey, previousChains := configFileArg, configKey, rewardsChainsArg
+	previousRPCs, previousAddress := rewardsRPCsArg, rewardsAddressArg
+	t.Cleanup(func() {
+		configFileArg, configKey, rewardsChainsArg = previousConfigFile, previousConfigKey, previousChains
+		rewardsRPCsArg, rewardsAddressArg = previousRPCs, previousAddress
+		rewardsChainTargets = nil
+	})
+
+	configFileArg = filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, writeConfig(configFileArg, &cliConfig{
+		PrivateKey: "0x01",
+		Chains: map[string]*chainConfig{
+			"a": {RPCURL: "http://a", TeleporterAddress: "0x000000000000000000000000000000000000000A"},
+			"b": {
+				RPCURL:            "http://b",
+				TeleporterAddress: "0x000000000000000000000000000000000000000B",
+				PrivateKey:        "0x02",
+			},
+			"c": {RPCURL: "http://c"},
+		},
+	}))
+
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSliceVar(&rewardsRPCsArg, "rpc", []string{}, "")
+		cmd.Flags().StringVar(&rewardsAddressArg, "teleporter-address", "", "")
+		cmd.Flags().StringSliceVar(&rewardsChainsArg, "chain", []string{}, "")
+		require.NoError(t, cmd.ParseFlags(args))
+		return cmd
+	}
+
+	// Each chain is queried with its own Teleporter address and key.
+	require.NoError(t, rewardsPreRunE(newCmd("--chain", "a,b"), nil))
+	require.Equal(t, []rewardsTarget{
+		{rpc: "http://a", address: "0x000000000000000000000000000000000000000A", key: keySource{privateKey: "0x01"}},
+		{rpc: "http://b", address: "0x000000000000000000000000000000000000000B", key: keySource{privateKey: "0x02"}},
+	}, rewardsChainTargets)
+	require.Equal(t, []string{"http://a", "http://b"}, rewardsRPCsArg)
+
+	// An explicit Teleporter address applies to every chain.
+	require.NoError(t, rewardsPreRunE(newCmd("--chain", "a,b", "--teleporter-address", "0x01"), nil))
+	require.Equal(t, "0x01", rewardsChainTargets[0].address)
+	require.Equal(t, "0x01", rewardsChainTargets[1].address)
+
+	// Explicit endpoints are not paired with the chains.
+	require.NoError(t, rewardsPreRunE(newCmd("--chain", "a,b", "--rpc", "http://d"), nil))
+	require.Nil(t, rewardsChainTargets)
+	require.Equal(t, "0x000000000000000000000000000000000000000A", rewardsAddressArg)
+
+	err := rewardsPreRunE(newCmd("--chain", "a,c"), nil)
+	require.ErrorContains(t, err, `chain "c" has no Teleporter address`)
+}
@@ -37,12 +37,17 @@ func Execute() {
 func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	logLevelArg := rootCmd.PersistentFlags().StringP("log", "l", "", "Log level i.e. debug, info...")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputTable, "Output format i.e. json, yaml, table")
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		return rootPreRunE(logLevelArg)
 	}
 }
 
 func rootPreRunE(logLevelArg *string) error {
+	if err := validateOutputFormat(outputFormat); err != nil {
+		return err
+	}
+
 	if *logLevelArg == "" {
 		*logLevelArg = logging.Info.LowerString()
 	}
@@ -55,7 +60,7 @@ func rootPreRunE(logLevelArg *string) error {
 		"teleporter-cli",
 		logging.NewWrappedCore(
 			logLevel,
-			os.Stdout,
+			os.Stderr,
 			logging.Plain.ConsoleEncoder(),
 		),
 	)
@@ -67,11 +72,12 @@ func rootPreRunE(logLevelArg *string) error {
 	return nil
 }
 
+// callPersistentPreRunE runs the persistent pre-run function of the root command, since cobra
+// only runs the nearest one to the executed command. The root command is called directly rather
+// than the parent, so that nested subcommands don't run the same pre-run function twice.
 func callPersistentPreRunE(cmd *cobra.Command, args []string) error {
-	if parent := cmd.Parent(); parent != nil {
-		if parent.PersistentPreRunE != nil {
-			return parent.PersistentPreRunE(parent, args)
-		}
+	if root := cmd.Root(); root != cmd && root.PersistentPreRunE != nil {
+		return root.PersistentPreRunE(root, args)
 	}
 	return nil
 }
@@ -6,6 +6,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/utils/logging"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 )
@@ -15,11 +18,39 @@ func executeTestCmd(t *testing.T, c *cobra.Command, args ...string) (string, err
 	c.SetOut(buf)
 	c.SetErr(buf)
 	c.SetArgs(args)
+	resetHelpFlags(c)
 
 	err := c.Execute()
 	return strings.TrimSpace(buf.String()), err
 }
 
+// setTestGlobals sets the globals otherwise initialized by the flags and pre-run function of the
+// root command, for tests that call the functions of commands directly.
+func setTestGlobals(t *testing.T, address common.Address) {
+	previousABI, previousLogger, previousAddress := teleporterABI, logger, teleporterAddress
+	t.Cleanup(func() {
+		teleporterABI, logger, teleporterAddress = previousABI, previousLogger, previousAddress
+	})
+
+	abi, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+	teleporterABI = abi
+	logger = logging.NoLog{}
+	teleporterAddress = address
+}
+
+// resetHelpFlags clears the help flags set by previous executions, which would otherwise cause
+// later executions of the same command to print its help instead of running it.
+func resetHelpFlags(c *cobra.Command) {
+	if f := c.Flags().Lookup("help"); f != nil {
+		_ = f.Value.Set("false")
+		f.Changed = false
+	}
+	for _, sub := range c.Commands() {
+		resetHelpFlags(sub)
+	}
+}
+
 func TestRootCmd(t *testing.T) {
 	var tests = []struct {
 		name string
@@ -0,0 +1,188 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterClient "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	destinationBlockchainIDArg string
+	destinationAddressArg      string
+	requiredGasLimitArg        uint64
+	feeTokenAddressArg         string
+	feeAmountArg               string
+	allowedRelayersArg         []string
+	payloadArg                 string
+	payloadFileArg             string
+
+	errMissingFeeTokenAddress = errors.New("fee token address must be set for a non-zero fee amount")
+)
+
+var sendCmd = &cobra.Command{
+	Use: "send --rpc RPC_URL --teleporter-address CONTRACT_ADDRESS --destination-blockchain-id BLOCKCHAIN_ID " +
+		"--destination-address ADDRESS --required-gas-limit GAS_LIMIT [--payload PAYLOAD | --payload-file FILE]",
+	Short: "Sends a Teleporter message",
+	Long: `Builds a call to sendCrossChainMessage from the given message input, signs it
+with the provided key, and submits it to the Teleporter contract. Once accepted,
+the message ID and nonce are read from the SendCrossChainMessage log of the
+transaction. If a non-zero fee is provided, the sender must have already approved
+the Teleporter contract to spend the fee amount of the fee token.`,
+	Args: cobra.NoArgs,
+	RunE: sendRunE,
+}
+
+func sendRunE(cmd *cobra.Command, args []string) error {
+	input, err := sendMessageInput()
+	if err != nil {
+		return err
+	}
+
+	signer, err := loadSigner(context.Background())
+	if err != nil {
+		return err
+	}
+
+	data, err := teleportermessenger.PackSendCrossChainMessage(input)
+	if err != nil {
+		return err
+	}
+
+	if dryRunArg {
+		return simulateAndPrintTransaction(context.Background(), cmd, client, signer, teleporterAddress, data, 0)
+	}
+	receipt, err := createAndSendTransaction(context.Background(), client, signer, teleporterAddress, data, 0)
+	if err != nil {
+		return err
+	}
+
+	messenger, err := teleportermessenger.NewTeleporterMessenger(teleporterAddress, client)
+	if err != nil {
+		return err
+	}
+	event, err := teleporterClient.EventFromLogs(receipt.Logs, teleporterAddress, messenger.ParseSendCrossChainMessage)
+	if err != nil {
+		return err
+	}
+
+	err = printOutput(cmd, sendOutput{
+		TxHash:       receipt.TxHash.Hex(),
+		MessageID:    common.Hash(event.MessageID).Hex(),
+		MessageNonce: event.Message.MessageNonce.String(),
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Println("Send command ran successfully")
+	return nil
+}
+
+// sendOutput is the output schema of the send command.
+type sendOutput struct {
+	TxHash       string `json:"txHash" yaml:"txHash"`
+	MessageID    string `json:"messageID" yaml:"messageID"`
+	MessageNonce string `json:"messageNonce" yaml:"messageNonce"`
+}
+
+// sendMessageInput constructs the TeleporterMessageInput from the command flags.
+func sendMessageInput() (teleportermessenger.TeleporterMessageInput, error) {
+	destinationBlockchainID, err := parseID(destinationBlockchainIDArg)
+	if err != nil {
+		return teleportermessenger.TeleporterMessageInput{}, err
+	}
+
+	destinationAddress, err := parseAddress(destinationAddressArg)
+	if err != nil {
+		return teleportermessenger.TeleporterMessageInput{}, err
+	}
+
+	feeInfo, err := parseFeeInfo(feeTokenAddressArg, feeAmountArg)
+	if err != nil {
+		return teleportermessenger.TeleporterMessageInput{}, err
+	}
+
+	allowedRelayers, err := parseAddresses(allowedRelayersArg)
+	if err != nil {
+		return teleportermessenger.TeleporterMessageInput{}, err
+	}
+
+	payload := []byte{}
+	if payloadArg != "" {
+		payload, err = parseHexBytes(payloadArg)
+		if err != nil {
+			return teleportermessenger.TeleporterMessageInput{}, err
+		}
+	}
+	if payloadFileArg != "" {
+		payload, err = os.ReadFile(payloadFileArg)
+		if err != nil {
+			return teleportermessenger.TeleporterMessageInput{}, err
+		}
+	}
+
+	return teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: destinationBlockchainID,
+		DestinationAddress:      destinationAddress,
+		FeeInfo:                 feeInfo,
+		RequiredGasLimit:        new(big.Int).SetUint64(requiredGasLimitArg),
+		AllowedRelayerAddresses: allowedRelayers,
+		Message:                 payload,
+	}, nil
+}
+
+// parseFeeInfo parses a relayer fee from its token address and amount flags. The token address
+// may only be omitted if the amount is zero.
+func parseFeeInfo(tokenAddressArg string, amountArg string) (teleportermessenger.TeleporterFeeInfo, error) {
+	amount, err := parseUint256(amountArg)
+	if err != nil {
+		return teleportermessenger.TeleporterFeeInfo{}, err
+	}
+
+	var tokenAddress common.Address
+	if tokenAddressArg != "" {
+		tokenAddress, err = parseAddress(tokenAddressArg)
+		if err != nil {
+			return teleportermessenger.TeleporterFeeInfo{}, err
+		}
+	}
+	if amount.Sign() > 0 && tokenAddress == (common.Address{}) {
+		return teleportermessenger.TeleporterFeeInfo{}, errMissingFeeTokenAddress
+	}
+
+	return teleportermessenger.TeleporterFeeInfo{
+		FeeTokenAddress: tokenAddress,
+		Amount:          amount,
+	}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(sendCmd)
+	addClientFlags(sendCmd)
+	addKeyFlags(sendCmd)
+	sendCmd.Flags().StringVar(&destinationBlockchainIDArg, "destination-blockchain-id", "",
+		"Destination blockchain ID, CB58 or hex encoded")
+	sendCmd.Flags().StringVar(&destinationAddressArg, "destination-address", "", "Destination contract address")
+	sendCmd.Flags().Uint64Var(&requiredGasLimitArg, "required-gas-limit", 0,
+		"Gas limit required to execute the message on the destination chain")
+	sendCmd.Flags().StringVar(&feeTokenAddressArg, "fee-token-address", "", "ERC20 contract address of the relayer fee")
+	sendCmd.Flags().StringVar(&feeAmountArg, "fee-amount", "0", "Relayer fee amount, in the fee token's smallest unit")
+	sendCmd.Flags().StringSliceVar(&allowedRelayersArg, "allowed-relayers", []string{},
+		"Addresses allowed to deliver the message. Any relayer may deliver the message if empty")
+	sendCmd.Flags().StringVar(&payloadArg, "payload", "", "Hex encoded message payload")
+	sendCmd.Flags().StringVar(&payloadFileArg, "payload-file", "", "Path to a file containing the raw message payload")
+	sendCmd.MarkFlagsMutuallyExclusive("payload", "payload-file")
+
+	for _, flag := range []string{"destination-blockchain-id", "destination-address", "required-gas-limit"} {
+		err := sendCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"send"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"send", "--help"},
+			err:  nil,
+			out:  "Builds a call to sendCrossChainMessage from the given message input",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestParseFeeInfo(t *testing.T) {
+	tokenAddress := common.HexToAddress("0x5DB9A7629912EBF95876228C24A848de0bfB43A9")
+
+	var tests = []struct {
+		name         string
+		tokenAddress string
+		amount       string
+		out          teleportermessenger.TeleporterFeeInfo
+		err          error
+	}{
+		{
+			name:         "token and amount",
+			tokenAddress: tokenAddress.Hex(),
+			amount:       "100",
+			out:          teleportermessenger.TeleporterFeeInfo{FeeTokenAddress: tokenAddress, Amount: big.NewInt(100)},
+		},
+		{
+			name:         "token and zero amount",
+			tokenAddress: tokenAddress.Hex(),
+			amount:       "0",
+			out:          teleportermessenger.TeleporterFeeInfo{FeeTokenAddress: tokenAddress, Amount: big.NewInt(0)},
+		},
+		{
+			name:   "no token and zero amount",
+			amount: "0",
+			out:    teleportermessenger.TeleporterFeeInfo{Amount: big.NewInt(0)},
+		},
+		{
+			name:   "no token and non-zero amount",
+			amount: "1",
+			err:    errMissingFeeTokenAddress,
+		},
+		{
+			name:         "zero token address and non-zero amount",
+			tokenAddress: common.Address{}.Hex(),
+			amount:       "1",
+			err:          errMissingFeeTokenAddress,
+		},
+		{
+			name:         "invalid token address",
+			tokenAddress: "0x1234",
+			amount:       "1",
+			err:          errors.New("invalid address 0x1234"),
+		},
+		{
+			name:         "negative amount",
+			tokenAddress: tokenAddress.Hex(),
+			amount:       "-1",
+			err:          errors.New("invalid uint256 -1"),
+		},
+		{
+			name:         "amount beyond uint256",
+			tokenAddress: tokenAddress.Hex(),
+			amount:       new(big.Int).Lsh(big.NewInt(1), 256).String(),
+			err:          errors.New("invalid uint256"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feeInfo, err := parseFeeInfo(tt.tokenAddress, tt.amount)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.out.FeeTokenAddress, feeInfo.FeeTokenAddress)
+			require.Equal(t, 0, tt.out.Amount.Cmp(feeInfo.Amount))
+		})
+	}
+}
+
+func TestSendMessageInputPayload(t *testing.T) {
+	previousDestinationBlockchainID, previousDestinationAddress := destinationBlockchainIDArg, destinationAddressArg
+	previousPayload, previousPayloadFile := payloadArg, payloadFileArg
+	t.Cleanup(func() {
+		destinationBlockchainIDArg, destinationAddressArg = previousDestinationBlockchainID, previousDestinationAddress
+		payloadArg, payloadFileArg = previousPayload, previousPayloadFile
+	})
+	destinationBlockchainIDArg = common.Hash{}.Hex()
+	destinationAddressArg = common.Address{}.Hex()
+	payloadFileArg = ""
+
+	var tests = []struct {
+		name    string
+		payload string
+		out     []byte
+		err     error
+	}{
+		{
+			name: "no payload",
+			out:  []byte{},
+		},
+		{
+			name:    "hex payload",
+			payload: "abcd",
+			out:     []byte{0xab, 0xcd},
+		},
+		{
+			name:    "prefixed hex payload",
+			payload: "0xabcd",
+			out:     []byte{0xab, 0xcd},
+		},
+		{
+			name:    "invalid payload",
+			payload: "0xabc",
+			err:     errors.New("invalid hex bytes 0xabc"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payloadArg = tt.payload
+			input, err := sendMessageInput()
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.out, input.Message)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	errRemoteSignerNoAccount   = errors.New("remote signer has no accounts")
+	errRemoteSignerAccounts    = errors.New("remote signer has multiple accounts, set --signer-address")
+	errRemoteSignerWrongTx     = errors.New("remote signer signed a different transaction than requested")
+	errRemoteSignerWrongFrom   = errors.New("remote signer signed the transaction with a different address")
+	errUnsupportedRemoteTxType = errors.New("remote signer only signs dynamic fee transactions")
+)
+
+// txSigner signs the transactions submitted by the write commands.
+type txSigner interface {
+	// Address returns the address that transactions are signed by.
+	Address() common.Address
+	// SignTx returns the transaction signed for its chain ID.
+	SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error)
+}
+
+var (
+	_ txSigner = &keySigner{}
+	_ txSigner = &remoteSigner{}
+)
+
+// keySigner signs transactions with a private key held in memory.
+type keySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+func newKeySigner(key *ecdsa.PrivateKey) *keySigner {
+	return &keySigner{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+func (s *keySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *keySigner) SignTx(_ context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(tx.ChainId()), s.key)
+}
+
+// remoteSigner signs transactions with the eth_signTransaction method of a JSON-RPC endpoint, such
+// as Clef or a node with an unlocked account, so that the key is never held by the CLI.
+type remoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// remoteTxArgs are the arguments of eth_signTransaction for a dynamic fee transaction.
+type remoteTxArgs struct {
+	From                 common.Address    `json:"from"`
+	To                   *common.Address   `json:"to"`
+	Gas                  hexutil.Uint64    `json:"gas"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big      `json:"value"`
+	Nonce                hexutil.Uint64    `json:"nonce"`
+	Data                 hexutil.Bytes     `json:"data"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+	ChainID              *hexutil.Big      `json:"chainId"`
+}
+
+// newRemoteSigner connects to the remote signer at the given URL. If address is the zero address,
+// the signer's only account is used.
+func newRemoteSigner(ctx context.Context, url string, address common.Address) (*remoteSigner, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote signer: %w", err)
+	}
+	if address == (common.Address{}) {
+		var accounts []common.Address
+		if err := client.CallContext(ctx, &accounts, "eth_accounts"); err != nil {
+			return nil, fmt.Errorf("failed to get remote signer accounts: %w", err)
+		}
+		switch len(accounts) {
+		case 0:
+			return nil, errRemoteSignerNoAccount
+		case 1:
+			address = accounts[0]
+		default:
+			return nil, errRemoteSignerAccounts
+		}
+	}
+	return &remoteSigner{
+		client:  client,
+		address: address,
+	}, nil
+}
+
+func (s *remoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx requests the signature of the transaction from the remote signer, and checks that the
+// signed transaction is the requested one, signed by the signer's address.
+func (s *remoteSigner) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	if tx.Type() != types.DynamicFeeTxType {
+		return nil, errUnsupportedRemoteTxType
+	}
+	accessList := tx.AccessList()
+	args := remoteTxArgs{
+		From:                 s.address,
+		To:                   tx.To(),
+		Gas:                  hexutil.Uint64(tx.Gas()),
+		MaxFeePerGas:         (*hexutil.Big)(tx.GasFeeCap()),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tx.GasTipCap()),
+		Value:                (*hexutil.Big)(tx.Value()),
+		Nonce:                hexutil.Uint64(tx.Nonce()),
+		Data:                 tx.Data(),
+		AccessList:           &accessList,
+		ChainID:              (*hexutil.Big)(tx.ChainId()),
+	}
+	var result json.RawMessage
+	if err := s.client.CallContext(ctx, &result, "eth_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("remote signer failed to sign transaction: %w", err)
+	}
+	raw, err := unmarshalSignTransactionResult(result)
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("failed to decode remotely signed transaction: %w", err)
+	}
+	signer := types.LatestSignerForChainID(tx.ChainId())
+	if signer.Hash(signedTx) != signer.Hash(tx) {
+		return nil, errRemoteSignerWrongTx
+	}
+	from, err := types.Sender(signer, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signature: %w", err)
+	}
+	if from != s.address {
+		return nil, errRemoteSignerWrongFrom
+	}
+	return signedTx, nil
+}
+
+// unmarshalSignTransactionResult returns the raw signed transaction of an eth_signTransaction
+// result, which is either an object with a raw field, as returned by geth and Clef, or the raw
+// transaction itself.
+func unmarshalSignTransactionResult(result json.RawMessage) ([]byte, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+	var signed struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &signed); err != nil {
+		return nil, fmt.Errorf("invalid eth_signTransaction result: %w", err)
+	}
+	return signed.Raw, nil
+}
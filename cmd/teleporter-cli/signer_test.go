@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/accounts/keystore"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// testSignerService implements the eth_accounts and eth_signTransaction methods of a remote signer.
+type testSignerService struct {
+	accounts []common.Address
+	key      *ecdsa.PrivateKey
+}
+
+func (s *testSignerService) Accounts() []common.Address {
+	return s.accounts
+}
+
+// testSignTransactionResult is the result of eth_signTransaction, as returned by geth and Clef.
+type testSignTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+func (s *testSignerService) SignTransaction(args remoteTxArgs) (*testSignTransactionResult, error) {
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    args.ChainID.ToInt(),
+		Nonce:      uint64(args.Nonce),
+		To:         args.To,
+		Gas:        uint64(args.Gas),
+		GasFeeCap:  args.MaxFeePerGas.ToInt(),
+		GasTipCap:  args.MaxPriorityFeePerGas.ToInt(),
+		Value:      args.Value.ToInt(),
+		Data:       args.Data,
+		AccessList: *args.AccessList,
+	})
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(tx.ChainId()), s.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &testSignTransactionResult{Raw: raw}, nil
+}
+
+func newTestRemoteSigner(t *testing.T, service *testSignerService) string {
+	server := rpc.NewServer(0)
+	require.NoError(t, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+func newTestTx() *types.Transaction {
+	to := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(43112),
+		Nonce:     7,
+		To:        &to,
+		Gas:       200_000,
+		GasFeeCap: big.NewInt(50_000_000_000),
+		GasTipCap: big.NewInt(1_000_000_000),
+		Value:     common.Big0,
+		Data:      []byte{0x01, 0x02, 0x03},
+	})
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	var tests = []struct {
+		name    string
+		service *testSignerService
+		address common.Address
+		err     error
+	}{
+		{
+			name:    "only account",
+			service: &testSignerService{accounts: []common.Address{address}, key: key},
+		},
+		{
+			name:    "signer address",
+			service: &testSignerService{accounts: []common.Address{{}, address}, key: key},
+			address: address,
+		},
+		{
+			name:    "no accounts",
+			service: &testSignerService{key: key},
+			err:     errRemoteSignerNoAccount,
+		},
+		{
+			name:    "multiple accounts",
+			service: &testSignerService{accounts: []common.Address{{}, address}, key: key},
+			err:     errRemoteSignerAccounts,
+		},
+		{
+			name:    "wrong key",
+			service: &testSignerService{accounts: []common.Address{address}, key: otherKey},
+			err:     errRemoteSignerWrongFrom,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			signer, err := newRemoteSigner(ctx, newTestRemoteSigner(t, tt.service), tt.address)
+			if err == nil {
+				require.Equal(t, address, signer.Address())
+				var signedTx *types.Transaction
+				signedTx, err = signer.SignTx(ctx, newTestTx())
+				if err == nil {
+					from, err := types.Sender(types.LatestSignerForChainID(signedTx.ChainId()), signedTx)
+					require.NoError(t, err)
+					require.Equal(t, address, from)
+				}
+			}
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestLoadKeystore(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+	keyJSON, err := keystore.EncryptKey(key, "passphrase", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	keystorePath := filepath.Join(dir, "keystore.json")
+	require.NoError(t, os.WriteFile(keystorePath, keyJSON, 0o600))
+	passwordPath := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(passwordPath, []byte("passphrase\n"), 0o600))
+	wrongPasswordPath := filepath.Join(dir, "wrong-password")
+	require.NoError(t, os.WriteFile(wrongPasswordPath, []byte("wrong"), 0o600))
+
+	defer func() { passwordFileArg = "" }()
+
+	passwordFileArg = passwordPath
+	loaded, err := keySource{keystore: keystorePath}.load()
+	require.NoError(t, err)
+	require.Equal(t, key.Address, crypto.PubkeyToAddress(loaded.PublicKey))
+
+	passwordFileArg = wrongPasswordPath
+	_, err = keySource{keystore: keystorePath}.load()
+	require.ErrorIs(t, err, keystore.ErrDecrypt)
+}
+
+func TestLoadSignerForKey(t *testing.T) {
+	previousKeyArgs, previousConfigKey := keyArgs, configKey
+	t.Cleanup(func() {
+		keyArgs, configKey = previousKeyArgs, previousConfigKey
+	})
+
+	chainKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	configPrivateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keyArgs = keySource{}
+	configKey = keySource{privateKey: hexutil.Encode(crypto.FromECDSA(configPrivateKey))}
+
+	// The given key is used in place of the key of the chain selected from the config file, which
+	// is left unchanged.
+	source := keySource{privateKey: hexutil.Encode(crypto.FromECDSA(chainKey))}
+	signer, err := loadSignerForKey(context.Background(), source)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(chainKey.PublicKey), signer.Address())
+	require.Equal(t, keySource{privateKey: hexutil.Encode(crypto.FromECDSA(configPrivateKey))}, configKey)
+
+	signer, err = loadSigner(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(configPrivateKey.PublicKey), signer.Address())
+}
@@ -0,0 +1,236 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/ava-labs/subnet-evm/ethclient"
+	teleporterUtils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sourceRPCArg                string
+	destinationRPCArg           string
+	statusAddressArg            string
+	statusDestinationAddressArg string
+	statusTxHashArg             string
+	statusMsgIDArg              string
+	lookBackBlocksArg           uint64
+
+	statusSourceChainArg      string
+	statusDestinationChainArg string
+)
+
+var statusCmd = &cobra.Command{
+	Use: "status --source-rpc RPC_URL --destination-rpc RPC_URL --teleporter-address CONTRACT_ADDRESS " +
+		"[--destination-teleporter-address CONTRACT_ADDRESS] (--tx-hash TRANSACTION_HASH | --message-id MESSAGE_ID)",
+	Short: "Reports the delivery status of a Teleporter message",
+	Long: `Given the source and destination chain RPC endpoints and either the hash of
+the transaction that sent a Teleporter message or the message's ID, reports
+whether the message has been sent, delivered to the destination chain, executed
+or failed to execute, and whether its receipt has been received back on the
+source chain. The Teleporter contract of the destination chain defaults to the
+same address as that of the source chain, and can be set with
+--destination-teleporter-address. The endpoints and Teleporter addresses can be
+read from the config file with --source-chain and --destination-chain.`,
+	Args:    cobra.NoArgs,
+	PreRunE: statusPreRunE,
+	RunE:    statusRunE,
+}
+
+// messageStatusOutput is the printed representation of a MessageStatus.
+type messageStatusOutput struct {
+	MessageID               string `json:"messageID" yaml:"messageID"`
+	SourceBlockchainID      string `json:"sourceBlockchainID" yaml:"sourceBlockchainID"`
+	DestinationBlockchainID string `json:"destinationBlockchainID" yaml:"destinationBlockchainID"`
+	Status                  string `json:"status" yaml:"status"`
+	Sent                    bool   `json:"sent" yaml:"sent"`
+	SendTxHash              string `json:"sendTxHash,omitempty" yaml:"sendTxHash,omitempty"`
+	Delivered               bool   `json:"delivered" yaml:"delivered"`
+	DeliveryTxHash          string `json:"deliveryTxHash,omitempty" yaml:"deliveryTxHash,omitempty"`
+	Executed                bool   `json:"executed" yaml:"executed"`
+	ExecutionFailed         bool   `json:"executionFailed" yaml:"executionFailed"`
+	ExecutionTxHash         string `json:"executionTxHash,omitempty" yaml:"executionTxHash,omitempty"`
+	ReceiptReceived         bool   `json:"receiptReceived" yaml:"receiptReceived"`
+	ReceiptTxHash           string `json:"receiptTxHash,omitempty" yaml:"receiptTxHash,omitempty"`
+}
+
+// statusPreRunE defaults the endpoints and Teleporter addresses from the chains selected from the
+// config file, each chain's Teleporter address being used on that chain. It runs before cobra checks
+// for required flags.
+func statusPreRunE(cmd *cobra.Command, args []string) error {
+	if statusSourceChainArg != "" {
+		source, err := selectChain(statusSourceChainArg)
+		if err != nil {
+			return err
+		}
+		err = setChainFlags(cmd, map[string]string{
+			"source-rpc":         source.RPCURL,
+			"teleporter-address": source.TeleporterAddress,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if statusDestinationChainArg != "" {
+		destination, err := selectChain(statusDestinationChainArg)
+		if err != nil {
+			return err
+		}
+		err = setChainFlags(cmd, map[string]string{
+			"destination-rpc":                destination.RPCURL,
+			"destination-teleporter-address": destination.TeleporterAddress,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func statusRunE(cmd *cobra.Command, args []string) error {
+	source, err := ethclient.Dial(sourceRPCArg)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	destination, err := ethclient.Dial(destinationRPCArg)
+	if err != nil {
+		return err
+	}
+	defer destination.Close()
+
+	ctx := context.Background()
+	sourceAddress, err := parseAddress(statusAddressArg)
+	if err != nil {
+		return err
+	}
+	destinationAddress := sourceAddress
+	if statusDestinationAddressArg != "" {
+		if destinationAddress, err = parseAddress(statusDestinationAddressArg); err != nil {
+			return err
+		}
+	}
+	var status *teleporterUtils.MessageStatus
+	if statusTxHashArg != "" {
+		txHash, parseErr := parseHash(statusTxHashArg)
+		if parseErr != nil {
+			return parseErr
+		}
+		status, err = teleporterUtils.GetMessageStatusFromTransaction(
+			ctx, source, destination, sourceAddress, destinationAddress, txHash, lookBackBlocksArg,
+		)
+	} else {
+		messageID, parseErr := parseID(statusMsgIDArg)
+		if parseErr != nil {
+			return parseErr
+		}
+		status, err = teleporterUtils.GetMessageStatus(
+			ctx, source, destination, sourceAddress, destinationAddress, messageID, lookBackBlocksArg,
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	out := newMessageStatusOutput(status)
+	if outputFormat == outputTable {
+		return writeMessageStatusTable(cmd.OutOrStdout(), out)
+	}
+	return printOutput(cmd, out)
+}
+
+func newMessageStatusOutput(status *teleporterUtils.MessageStatus) messageStatusOutput {
+	return messageStatusOutput{
+		MessageID:               common.Hash(status.MessageID).Hex(),
+		SourceBlockchainID:      status.SourceBlockchainID.String(),
+		DestinationBlockchainID: status.DestinationBlockchainID.String(),
+		Status:                  messageStatusSummary(status),
+		Sent:                    status.Sent,
+		SendTxHash:              txHashString(status.SendTxHash),
+		Delivered:               status.Delivered,
+		DeliveryTxHash:          txHashString(status.DeliveryTxHash),
+		Executed:                status.Executed,
+		ExecutionFailed:         status.ExecutionFailed,
+		ExecutionTxHash:         txHashString(status.ExecutionTxHash),
+		ReceiptReceived:         status.ReceiptReceived,
+		ReceiptTxHash:           txHashString(status.ReceiptTxHash),
+	}
+}
+
+// messageStatusSummary describes the furthest stage the message has reached. A failed execution
+// that has not been retried successfully is reported even if a receipt for the message has been
+// received, since the message still needs to be retried.
+func messageStatusSummary(status *teleporterUtils.MessageStatus) string {
+	switch {
+	case status.ExecutionFailed && !status.Executed:
+		return "execution failed"
+	case status.ReceiptReceived:
+		return "receipt received"
+	case status.Executed:
+		return "executed"
+	case status.Delivered:
+		return "delivered"
+	case status.Sent:
+		return "sent"
+	default:
+		return "not found"
+	}
+}
+
+func txHashString(txHash common.Hash) string {
+	if txHash == (common.Hash{}) {
+		return ""
+	}
+	return txHash.Hex()
+}
+
+func writeMessageStatusTable(w io.Writer, out messageStatusOutput) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	rows := [][2]string{
+		{"Message ID", out.MessageID},
+		{"Source blockchain ID", out.SourceBlockchainID},
+		{"Destination blockchain ID", out.DestinationBlockchainID},
+		{"Status", out.Status},
+		{"Sent", fmt.Sprintf("%t\t%s", out.Sent, out.SendTxHash)},
+		{"Delivered", fmt.Sprintf("%t\t%s", out.Delivered, out.DeliveryTxHash)},
+		{"Executed", fmt.Sprintf("%t\t%s", out.Executed, out.ExecutionTxHash)},
+		{"Execution failed", fmt.Sprintf("%t", out.ExecutionFailed)},
+		{"Receipt received", fmt.Sprintf("%t\t%s", out.ReceiptReceived, out.ReceiptTxHash)},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(tw, "%s:\t%s\n", row[0], row[1])
+	}
+	return tw.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().StringVar(&sourceRPCArg, "source-rpc", "", "RPC endpoint of the source chain")
+	statusCmd.Flags().StringVar(&destinationRPCArg, "destination-rpc", "", "RPC endpoint of the destination chain")
+	statusCmd.Flags().StringVarP(&statusAddressArg, "teleporter-address", "t", "", "Teleporter contract address")
+	statusCmd.Flags().StringVar(&statusDestinationAddressArg, "destination-teleporter-address", "",
+		"Teleporter contract address on the destination chain. Defaults to --teleporter-address")
+	statusCmd.Flags().StringVar(&statusTxHashArg, "tx-hash", "", "Hash of the transaction that sent the message")
+	statusCmd.Flags().StringVar(&statusMsgIDArg, "message-id", "", "ID of the message, CB58 or hex encoded")
+	statusCmd.Flags().Uint64Var(&lookBackBlocksArg, "look-back-blocks", teleporterUtils.DefaultLookBackBlocks,
+		"Number of recent blocks to search for Teleporter logs when their block is not known")
+	statusCmd.Flags().StringVar(&statusSourceChainArg, "source-chain", "",
+		"Name of the source chain in the config file")
+	statusCmd.Flags().StringVar(&statusDestinationChainArg, "destination-chain", "",
+		"Name of the destination chain in the config file")
+	statusCmd.MarkFlagsMutuallyExclusive("tx-hash", "message-id")
+	statusCmd.MarkFlagsOneRequired("tx-hash", "message-id")
+
+	for _, flag := range []string{"source-rpc", "destination-rpc", "teleporter-address"} {
+		err := statusCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+}
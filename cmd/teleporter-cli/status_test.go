@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	teleporterUtils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"status"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"status", "--help"},
+			err:  nil,
+			out:  "Given the source and destination chain RPC endpoints",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestStatusPreRunE(t *testing.T) {
+	previousConfigFile, previousConfigKey := configFileArg, configKey
+	previousSourceChain, previousDestinationChain := statusSourceChainArg, statusDestinationChainArg
+	previousSourceRPC, previousDestinationRPC := sourceRPCArg, destinationRPCArg
+	previousAddress, previousDestinationAddress := statusAddressArg, statusDestinationAddressArg
+	t.Cleanup(func() {
+		configFileArg, configKey = previousConfigFile, previousConfigKey
+		statusSourceChainArg, statusDestinationChainArg = previousSourceChain, previousDestinationChain
+		sourceRPCArg, destinationRPCArg = previousSourceRPC, previousDestinationRPC
+		statusAddressArg, statusDestinationAddressArg = previousAddress, previousDestinationAddress
+	})
+
+	configFileArg = filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, writeConfig(configFileArg, &cliConfig{
+		Chains: map[string]*chainConfig{
+			"a": {RPCURL: "http://a", TeleporterAddress: "0x000000000000000000000000000000000000000A"},
+			"b": {RPCURL: "http://b", TeleporterAddress: "0x000000000000000000000000000000000000000B"},
+		},
+	}))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringVar(&sourceRPCArg, "source-rpc", "", "")
+	cmd.Flags().StringVar(&destinationRPCArg, "destination-rpc", "", "")
+	cmd.Flags().StringVar(&statusAddressArg, "teleporter-address", "", "")
+	cmd.Flags().StringVar(&statusDestinationAddressArg, "destination-teleporter-address", "", "")
+	statusSourceChainArg, statusDestinationChainArg = "a", "b"
+
+	// Each chain's Teleporter address is used on that chain.
+	require.NoError(t, statusPreRunE(cmd, nil))
+	require.Equal(t, "http://a", sourceRPCArg)
+	require.Equal(t, "http://b", destinationRPCArg)
+	require.Equal(t, "0x000000000000000000000000000000000000000A", statusAddressArg)
+	require.Equal(t, "0x000000000000000000000000000000000000000B", statusDestinationAddressArg)
+}
+
+func TestMessageStatusSummary(t *testing.T) {
+	var tests = []struct {
+		name   string
+		status teleporterUtils.MessageStatus
+		out    string
+	}{
+		{
+			name: "not found",
+			out:  "not found",
+		},
+		{
+			name:   "sent",
+			status: teleporterUtils.MessageStatus{Sent: true},
+			out:    "sent",
+		},
+		{
+			name:   "delivered",
+			status: teleporterUtils.MessageStatus{Sent: true, Delivered: true},
+			out:    "delivered",
+		},
+		{
+			name:   "executed",
+			status: teleporterUtils.MessageStatus{Sent: true, Delivered: true, Executed: true},
+			out:    "executed",
+		},
+		{
+			name:   "execution failed",
+			status: teleporterUtils.MessageStatus{Sent: true, Delivered: true, ExecutionFailed: true},
+			out:    "execution failed",
+		},
+		{
+			name: "execution failed with receipt",
+			status: teleporterUtils.MessageStatus{
+				Sent: true, Delivered: true, ExecutionFailed: true, ReceiptReceived: true,
+			},
+			out: "execution failed",
+		},
+		{
+			name: "execution failed then retried",
+			status: teleporterUtils.MessageStatus{
+				Sent: true, Delivered: true, ExecutionFailed: true, Executed: true,
+			},
+			out: "executed",
+		},
+		{
+			name: "execution retried with receipt",
+			status: teleporterUtils.MessageStatus{
+				Sent: true, Delivered: true, ExecutionFailed: true, Executed: true, ReceiptReceived: true,
+			},
+			out: "receipt received",
+		},
+		{
+			name:   "executed with receipt",
+			status: teleporterUtils.MessageStatus{Sent: true, Delivered: true, Executed: true, ReceiptReceived: true},
+			out:    "receipt received",
+		},
+		{
+			// The send log may be outside the searched block range.
+			name:   "receipt without send log",
+			status: teleporterUtils.MessageStatus{Delivered: true, Executed: true, ReceiptReceived: true},
+			out:    "receipt received",
+		},
+		{
+			name:   "execution failed without send log",
+			status: teleporterUtils.MessageStatus{Delivered: true, ExecutionFailed: true},
+			out:    "execution failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.out, messageStatusSummary(&tt.status))
+		})
+	}
+
+	// A failed execution that was not retried is never hidden by a later stage.
+	for mask := 0; mask < 1<<5; mask++ {
+		status := teleporterUtils.MessageStatus{
+			Sent:            mask&1 != 0,
+			Delivered:       mask&2 != 0,
+			Executed:        mask&4 != 0,
+			ExecutionFailed: mask&8 != 0,
+			ReceiptReceived: mask&16 != 0,
+		}
+		summary := messageStatusSummary(&status)
+		if status.ExecutionFailed && !status.Executed {
+			require.Equal(t, "execution failed", summary, "%+v", status)
+		} else {
+			require.NotEqual(t, "execution failed", summary, "%+v", status)
+		}
+		require.Equal(t, mask == 0, summary == "not found", "%+v", status)
+	}
+}
@@ -5,95 +5,124 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 
-	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
-	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ava-labs/subnet-evm/x/warp"
-	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
-const (
-	warpPrecompileAddress = "0x0200000000000000000000000000000000000005"
-)
-
-var (
-	rpcEndpoint       string
-	teleporterAddress common.Address
-	client            ethclient.Client
-)
-
 var transactionCmd = &cobra.Command{
 	Use:   "transaction --rpc RPC_URL --teleporter-address CONTRACT_ADDRESS TRANSACTION_HASH",
 	Short: "Parses relevant Teleporter logs from a transaction",
 	Long: `Given a transaction this command looks through the transaction's receipt
 for Teleporter and Warp log events. When corresponding log events are found,
-the command parses to log event fields to a more human readable format.`,
+the command parses to log event fields to a more human readable format. If the
+transaction calls the Teleporter contract, its input is also decoded, along with
+any signed Warp messages in its access list predicates, so that transactions that
+reverted without emitting logs can be inspected.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		receipt, err := client.TransactionReceipt(context.Background(),
 			common.HexToHash(args[0]))
 		cobra.CheckErr(err)
 
-		for _, log := range receipt.Logs {
-			if log.Address == teleporterAddress {
-				logger.Info("Processing Teleporter log", zap.Any("log", log))
-
-				event, err := teleporterABI.EventByID(log.Topics[0])
-				cobra.CheckErr(err)
-
-				out, err := teleportermessenger.FilterTeleporterEvents(log.Topics, log.Data, event.Name)
-				cobra.CheckErr(err)
-				logger.Info("Parsed Teleporter event", zap.String("name", event.Name), zap.Any("event", out))
-			}
-
-			if log.Address == common.HexToAddress(warpPrecompileAddress) {
-				logger.Debug("Processing Warp log", zap.Any("log", log))
-
-				unsignedMsg, err := warp.UnpackSendWarpEventDataToMessage(log.Data)
-				cobra.CheckErr(err)
-
-				warpPayload, err := warpPayload.ParseAddressedCall(unsignedMsg.Payload)
-				cobra.CheckErr(err)
+		tx, _, err := client.TransactionByHash(context.Background(), receipt.TxHash)
+		cobra.CheckErr(err)
+		input, err := decodeTransactionInput(tx)
+		cobra.CheckErr(err)
 
-				teleporterMessage, err := teleportermessenger.UnpackTeleporterMessage(warpPayload.Payload)
-				cobra.CheckErr(err)
-				logger.Info("Parsed Teleporter message",
-					zap.String("warpMessageID", unsignedMsg.ID().Hex()),
-					zap.String("teleporterMessageNonce", teleporterMessage.MessageNonce.String()),
-					zap.Any("message", teleporterMessage))
+		out := transactionOutput{
+			TxHash:      receipt.TxHash.Hex(),
+			BlockNumber: receipt.BlockNumber.Uint64(),
+			Status:      receipt.Status,
+			Input:       input,
+			Logs:        []logOutput{},
+		}
+		for _, log := range receipt.Logs {
+			decoded, err := decodeLog(log)
+			cobra.CheckErr(err)
+			if decoded != nil {
+				out.Logs = append(out.Logs, decoded.Output)
 			}
 		}
+		err = printOutput(cmd, out)
+		cobra.CheckErr(err)
 		cmd.Println("Transaction command ran successfully")
 	},
 }
 
-func init() {
-	rootCmd.AddCommand(transactionCmd)
-	transactionCmd.PersistentFlags().StringVar(&rpcEndpoint, "rpc", "", "RPC endpoint to connect to the node")
-	address := transactionCmd.PersistentFlags().StringP("teleporter-address", "t", "", "Teleporter contract address")
-	err := transactionCmd.MarkPersistentFlagRequired("rpc")
-	cobra.CheckErr(err)
-	err = transactionCmd.MarkPersistentFlagRequired("teleporter-address")
-	cobra.CheckErr(err)
-	transactionCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		return transactionPreRunE(cmd, args, address)
-	}
+// transactionOutput is the output schema of the transaction command.
+type transactionOutput struct {
+	TxHash      string       `json:"txHash" yaml:"txHash"`
+	BlockNumber uint64       `json:"blockNumber" yaml:"blockNumber"`
+	Status      uint64       `json:"status" yaml:"status"`
+	Input       *inputOutput `json:"input,omitempty" yaml:"input,omitempty"`
+	Logs        []logOutput  `json:"logs" yaml:"logs"`
+}
+
+// inputOutput is the output schema of the decoded input of a transaction that calls the Teleporter contract.
+// Method and Args are empty if the selector is not a Teleporter method.
+type inputOutput struct {
+	Selector     string                 `json:"selector" yaml:"selector"`
+	Method       string                 `json:"method,omitempty" yaml:"method,omitempty"`
+	Args         map[string]interface{} `json:"args" yaml:"args"`
+	WarpMessages []*warpMessageOutput   `json:"warpMessages,omitempty" yaml:"warpMessages,omitempty"`
 }
 
-func transactionPreRunE(cmd *cobra.Command, args []string, address *string) error {
-	// Run the persistent pre-run function of the root command if it exists.
-	if err := callPersistentPreRunE(cmd, args); err != nil {
-		return err
+// decodeTransactionInput decodes the Teleporter method called by the transaction, and the signed Warp
+// messages in its access list predicates. The Warp message index passed to receiveCrossChainMessage
+// refers to the position of the message in WarpMessages. Returns nil if the transaction does not call
+// the Teleporter contract. An unknown selector is reported without a method, so that the rest of the
+// transaction can still be inspected.
+func decodeTransactionInput(tx *types.Transaction) (*inputOutput, error) {
+	if tx.To() == nil || *tx.To() != teleporterAddress || len(tx.Data()) < 4 {
+		return nil, nil
 	}
-	teleporterAddress = common.HexToAddress(*address)
-	c, err := ethclient.Dial(rpcEndpoint)
+
+	out := &inputOutput{
+		Selector: hexString(tx.Data()[:4]),
+		Args:     map[string]interface{}{},
+	}
+	method, err := teleporterABI.MethodById(tx.Data()[:4])
 	if err != nil {
-		return err
+		logger.Warn("Skipping input with unknown Teleporter method selector",
+			zap.String("selector", out.Selector),
+			zap.String("txHash", tx.Hash().Hex()))
+	} else {
+		values, err := method.Inputs.Unpack(tx.Data()[4:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack %s input: %w", method.Name, err)
+		}
+		out.Method = method.Name
+		for i, value := range values {
+			out.Args[method.Inputs[i].Name] = toOutputValue(reflect.ValueOf(value))
+		}
 	}
 
-	client = c
-	return err
+	for _, tuple := range tx.AccessList() {
+		if tuple.Address != warp.ContractAddress {
+			continue
+		}
+		// Predicates that fail to decode are kept as nil entries to preserve the message indices.
+		var warpMessage *warpMessageOutput
+		msg, err := parseSignedWarpMessage(utils.HashSliceToBytes(tuple.StorageKeys), true)
+		if err == nil {
+			warpMessage, err = newWarpMessageOutput(msg)
+		}
+		if err != nil {
+			logger.Warn("Failed to decode Warp message from access list predicate", zap.Error(err))
+		}
+		out.WarpMessages = append(out.WarpMessages, warpMessage)
+	}
+	return out, nil
+}
+
+func init() {
+	rootCmd.AddCommand(transactionCmd)
+	addClientFlags(transactionCmd)
 }
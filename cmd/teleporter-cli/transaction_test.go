@@ -2,8 +2,18 @@ package main
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/core/types"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/x/warp"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,3 +49,81 @@ func TestTransactionCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeTransactionInput(t *testing.T) {
+	setTestGlobals(t, common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"))
+
+	relayer := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	messageBytes, err := teleportermessenger.PackTeleporterMessage(teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		DestinationBlockchainID: ids.ID{1, 2, 3},
+		RequiredGasLimit:        big.NewInt(100_000),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 []byte{1, 2},
+	})
+	require.NoError(t, err)
+	addressedCall, err := warpPayload.NewAddressedCall(teleporterAddress.Bytes(), messageBytes)
+	require.NoError(t, err)
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(1, ids.ID{4, 5, 6}, addressedCall.Bytes())
+	require.NoError(t, err)
+	signedMsg, err := avalancheWarp.NewMessage(unsignedMsg, &avalancheWarp.BitSetSignature{
+		Signers: set.NewBits(0, 2).Bytes(),
+	})
+	require.NoError(t, err)
+
+	newTx := func(to common.Address, data []byte) *types.Transaction {
+		// The first Warp predicate can't be decoded, so the signed message is at index 1. The
+		// access list entry of another contract is not a Warp predicate.
+		accessList := types.AccessList{
+			{Address: common.HexToAddress("0x1"), StorageKeys: []common.Hash{{1}}},
+			{Address: warp.ContractAddress, StorageKeys: []common.Hash{{1}}},
+		}
+		return predicateutils.NewPredicateTx(
+			big.NewInt(1), 0, &to, 1_000_000, big.NewInt(1), big.NewInt(1), big.NewInt(0), data,
+			accessList, warp.ContractAddress, signedMsg.Bytes(),
+		)
+	}
+
+	data, err := teleportermessenger.PackReceiveCrossChainMessage(1, relayer)
+	require.NoError(t, err)
+	input, err := decodeTransactionInput(newTx(teleporterAddress, data))
+	require.NoError(t, err)
+	require.Equal(t, hexString(data[:4]), input.Selector)
+	require.Equal(t, "receiveCrossChainMessage", input.Method)
+	require.Equal(t, map[string]interface{}{
+		"messageIndex":         uint32(1),
+		"relayerRewardAddress": relayer.Hex(),
+	}, input.Args)
+
+	// The message index passed to receiveCrossChainMessage refers to the position in WarpMessages.
+	require.Len(t, input.WarpMessages, 2)
+	require.Nil(t, input.WarpMessages[0])
+	expected, err := newWarpMessageOutput(signedMsg)
+	require.NoError(t, err)
+	require.Equal(t, expected, input.WarpMessages[1])
+	require.Equal(t, []int{0, 2}, input.WarpMessages[1].SignerIndices)
+	require.Equal(t, hexString(messageBytes), input.WarpMessages[1].Payload)
+
+	// Transactions that do not call the Teleporter contract are not decoded.
+	input, err = decodeTransactionInput(newTx(common.HexToAddress("0x1"), data))
+	require.NoError(t, err)
+	require.Nil(t, input)
+
+	input, err = decodeTransactionInput(newTx(teleporterAddress, data[:3]))
+	require.NoError(t, err)
+	require.Nil(t, input)
+
+	// Calldata with a known selector but missing arguments fails to unpack.
+	_, err = decodeTransactionInput(newTx(teleporterAddress, data[:4]))
+	require.ErrorContains(t, err, "failed to unpack receiveCrossChainMessage input")
+
+	// An unknown selector is reported without a method, along with the Warp messages.
+	input, err = decodeTransactionInput(newTx(teleporterAddress, []byte{0xde, 0xad, 0xbe, 0xef, 1}))
+	require.NoError(t, err)
+	require.Equal(t, "0xdeadbeef", input.Selector)
+	require.Empty(t, input.Method)
+	require.Empty(t, input.Args)
+	require.Len(t, input.WarpMessages, 2)
+	require.Equal(t, expected, input.WarpMessages[1])
+}
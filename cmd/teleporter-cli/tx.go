@@ -0,0 +1,193 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/x/warp"
+	gasUtils "github.com/ava-labs/teleporter/utils/gas-utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	transactionTimeout = 30 * time.Second
+)
+
+// createTransaction constructs a dynamic fee transaction from the given sender calling the
+// contract at the given address with the provided call data. The gas limit is estimated
+// against the given client and raised to minGasLimit if lower, and the fee caps are
+// calculated from the client's current base fee.
+func createTransaction(
+	ctx context.Context,
+	client ethclient.Client,
+	from common.Address,
+	to common.Address,
+	data []byte,
+	minGasLimit uint64,
+) (*types.Transaction, error) {
+	gasLimit, err := client.EstimateGas(ctx, interfaces.CallMsg{
+		From: from,
+		To:   &to,
+		Data: data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	if gasLimit < minGasLimit {
+		gasLimit = minGasLimit
+	}
+	return createTransactionWithGasLimit(ctx, client, from, to, data, gasLimit)
+}
+
+// createTransactionWithGasLimit constructs a dynamic fee transaction like createTransaction, with
+// the given gas limit rather than an estimate.
+func createTransactionWithGasLimit(
+	ctx context.Context,
+	client ethclient.Client,
+	from common.Address,
+	to common.Address,
+	data []byte,
+	gasLimit uint64,
+) (*types.Transaction, error) {
+	params, err := calculateTxParams(ctx, client, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   params.chainID,
+		Nonce:     params.nonce,
+		To:        &to,
+		Gas:       gasLimit,
+		GasFeeCap: params.gasFeeCap,
+		GasTipCap: params.gasTipCap,
+		Value:     common.Big0,
+		Data:      data,
+	}), nil
+}
+
+// createPredicateTransaction constructs a dynamic fee transaction like createTransaction, with
+// the signed Warp message included as a predicate in its access list. The gas limit is not
+// estimated, since predicates are not verified by eth_estimateGas.
+func createPredicateTransaction(
+	ctx context.Context,
+	client ethclient.Client,
+	from common.Address,
+	to common.Address,
+	data []byte,
+	gasLimit uint64,
+	signedWarpMessage []byte,
+) (*types.Transaction, error) {
+	params, err := calculateTxParams(ctx, client, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return predicateutils.NewPredicateTx(
+		params.chainID,
+		params.nonce,
+		&to,
+		gasLimit,
+		params.gasFeeCap,
+		params.gasTipCap,
+		common.Big0,
+		data,
+		types.AccessList{},
+		warp.ContractAddress,
+		signedWarpMessage,
+	), nil
+}
+
+// txParams are the chain ID, sender nonce and fee caps of a transaction.
+type txParams struct {
+	chainID   *big.Int
+	nonce     uint64
+	gasFeeCap *big.Int
+	gasTipCap *big.Int
+}
+
+func calculateTxParams(ctx context.Context, client ethclient.Client, from common.Address) (*txParams, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	baseFee, err := client.EstimateBaseFee(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate base fee: %w", err)
+	}
+
+	gasTipCap, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest gas tip cap: %w", err)
+	}
+
+	nonce, err := client.NonceAt(ctx, from, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	gasFeeCap := baseFee.Mul(baseFee, big.NewInt(gasUtils.BaseFeeFactor))
+	gasFeeCap.Add(gasFeeCap, big.NewInt(gasUtils.MaxPriorityFeePerGas))
+
+	return &txParams{
+		chainID:   chainID,
+		nonce:     nonce,
+		gasFeeCap: gasFeeCap,
+		gasTipCap: gasTipCap,
+	}, nil
+}
+
+// sendTransaction submits a signed transaction and waits for it to be accepted.
+// Returns an error if the transaction is not accepted in time or reverts.
+func sendTransaction(ctx context.Context, client ethclient.Client, tx *types.Transaction) (*types.Receipt, error) {
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, transactionTimeout)
+	defer cancel()
+
+	receipt, err := bind.WaitMined(cctx, client, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return receipt, fmt.Errorf("transaction %s failed", tx.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+// createAndSendTransaction signs and submits a transaction calling the contract at the given
+// address with the provided call data, and waits for it to be accepted. The gas limit is at
+// least minGasLimit.
+func createAndSendTransaction(
+	ctx context.Context,
+	client ethclient.Client,
+	signer txSigner,
+	to common.Address,
+	data []byte,
+	minGasLimit uint64,
+) (*types.Receipt, error) {
+	tx, err := createTransaction(ctx, client, signer.Address(), to, data, minGasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx, err := signer.SignTx(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return sendTransaction(ctx, client, signedTx)
+}
@@ -0,0 +1,391 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	teleporterClient "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/client"
+	teleporterregistry "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/upgrades/TeleporterRegistry"
+	teleporterupgradeable "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/upgrades/TeleporterUpgradeable"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	upgradeableAddress common.Address
+
+	errMinVersionNotIncreased  = errors.New("version is not greater than the current minimum Teleporter version")
+	errMinVersionAboveLatest   = errors.New("version is greater than the registry's latest version")
+	errZeroTeleporterAddress   = errors.New("teleporter address must be non-zero")
+	errTeleporterAddressPaused = errors.New("teleporter address is already paused")
+	errTeleporterAddressActive = errors.New("teleporter address is not paused")
+)
+
+var upgradeableCmd = &cobra.Command{
+	Use:   "upgradeable",
+	Short: "Inspects and administers a TeleporterUpgradeable contract",
+	Long: `The upgradeable subcommands read and update the minimum Teleporter version and
+the paused Teleporter addresses of any contract that inherits from
+TeleporterUpgradeable. Updates must be submitted by an address allowed by the
+contract's access control, e.g. its owner.`,
+}
+
+var upgradeableStatusCmd = &cobra.Command{
+	Use:   "status --rpc RPC_URL --contract-address CONTRACT_ADDRESS",
+	Short: "Shows the contract's minimum Teleporter version and paused addresses",
+	Long: `Shows the minimum Teleporter version of the TeleporterUpgradeable contract and
+the TeleporterRegistry it uses. Each version registered in the registry is
+listed with its protocol address, whether the contract has paused the address,
+and whether the contract accepts messages delivered by it.`,
+	Args: cobra.NoArgs,
+	RunE: upgradeableStatusRunE,
+}
+
+var upgradeableUpdateMinVersionCmd = &cobra.Command{
+	Use:   "update-min-version --rpc RPC_URL --contract-address CONTRACT_ADDRESS VERSION",
+	Short: "Updates the contract's minimum Teleporter version",
+	Long: `Given a Teleporter version, this command checks that it is greater than the
+contract's current minimum Teleporter version and not greater than the
+registry's latest version, then submits updateMinTeleporterVersion. The old and
+new minimum versions are read from the MinTeleporterVersionUpdated log of the
+transaction.`,
+	Args: cobra.ExactArgs(1),
+	RunE: upgradeableUpdateMinVersionRunE,
+}
+
+var upgradeablePauseCmd = &cobra.Command{
+	Use:   "pause --rpc RPC_URL --contract-address CONTRACT_ADDRESS TELEPORTER_ADDRESS",
+	Short: "Pauses receiving messages from a Teleporter address",
+	Long: `Given a Teleporter contract address, this command submits pauseTeleporterAddress
+so that the contract stops receiving messages delivered by it, and stops sending
+messages through it if it is the latest version. The paused address is read
+from the TeleporterAddressPaused log of the transaction.`,
+	Args: cobra.ExactArgs(1),
+	RunE: upgradeablePauseRunE,
+}
+
+var upgradeableUnpauseCmd = &cobra.Command{
+	Use:   "unpause --rpc RPC_URL --contract-address CONTRACT_ADDRESS TELEPORTER_ADDRESS",
+	Short: "Unpauses receiving messages from a Teleporter address",
+	Long: `Given a paused Teleporter contract address, this command submits
+unpauseTeleporterAddress so that the contract resumes receiving messages
+delivered by it. The unpaused address is read from the TeleporterAddressUnpaused
+log of the transaction.`,
+	Args: cobra.ExactArgs(1),
+	RunE: upgradeableUnpauseRunE,
+}
+
+// upgradeableStatusOutput is the output schema of the upgradeable status command.
+type upgradeableStatusOutput struct {
+	MinTeleporterVersion string                     `json:"minTeleporterVersion" yaml:"minTeleporterVersion"`
+	RegistryAddress      string                     `json:"registryAddress" yaml:"registryAddress"`
+	LatestVersion        string                     `json:"latestVersion" yaml:"latestVersion"`
+	Versions             []upgradeableVersionOutput `json:"versions" yaml:"versions"`
+}
+
+// upgradeableVersionOutput is the status of a registered Teleporter version for the contract.
+// Accepted is true if the contract receives messages delivered by the version's protocol address,
+// which is checked against the highest version the address is registered under rather than Version.
+type upgradeableVersionOutput struct {
+	Version         string `json:"version" yaml:"version"`
+	ProtocolAddress string `json:"protocolAddress" yaml:"protocolAddress"`
+	Paused          bool   `json:"paused" yaml:"paused"`
+	Accepted        bool   `json:"accepted" yaml:"accepted"`
+}
+
+// upgradeableMinVersionOutput is the output schema of the upgradeable update-min-version command.
+type upgradeableMinVersionOutput struct {
+	TxHash                  string `json:"txHash" yaml:"txHash"`
+	OldMinTeleporterVersion string `json:"oldMinTeleporterVersion" yaml:"oldMinTeleporterVersion"`
+	NewMinTeleporterVersion string `json:"newMinTeleporterVersion" yaml:"newMinTeleporterVersion"`
+}
+
+// upgradeablePauseOutput is the output schema of the upgradeable pause and unpause commands.
+type upgradeablePauseOutput struct {
+	TxHash            string `json:"txHash" yaml:"txHash"`
+	TeleporterAddress string `json:"teleporterAddress" yaml:"teleporterAddress"`
+	Paused            bool   `json:"paused" yaml:"paused"`
+}
+
+func upgradeableStatusRunE(cmd *cobra.Command, args []string) error {
+	upgradeable, err := teleporterupgradeable.NewTeleporterUpgradeableCaller(upgradeableAddress, client)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: context.Background()}
+	minVersion, err := upgradeable.GetMinTeleporterVersion(opts)
+	if err != nil {
+		return err
+	}
+	registryAddress, err := upgradeable.TeleporterRegistry(opts)
+	if err != nil {
+		return err
+	}
+	registry, err := teleporterregistry.NewTeleporterRegistryCaller(registryAddress, client)
+	if err != nil {
+		return err
+	}
+	latestVersion, err := registry.LatestVersion(opts)
+	if err != nil {
+		return err
+	}
+
+	out := upgradeableStatusOutput{
+		MinTeleporterVersion: minVersion.String(),
+		RegistryAddress:      registryAddress.Hex(),
+		LatestVersion:        latestVersion.String(),
+		Versions:             []upgradeableVersionOutput{},
+	}
+	for version := big.NewInt(1); version.Cmp(latestVersion) <= 0; version = new(big.Int).Add(version, common.Big1) {
+		protocolAddress, err := registry.GetAddressFromVersion(opts, version)
+		if isRegistryVersionNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		paused, err := upgradeable.IsTeleporterAddressPaused(opts, protocolAddress)
+		if err != nil {
+			return err
+		}
+		addressVersion, err := registry.GetVersionFromAddress(opts, protocolAddress)
+		if err != nil {
+			return err
+		}
+		out.Versions = append(out.Versions, upgradeableVersionOutput{
+			Version:         version.String(),
+			ProtocolAddress: protocolAddress.Hex(),
+			Paused:          paused,
+			Accepted:        isTeleporterAddressAccepted(paused, addressVersion, minVersion),
+		})
+	}
+
+	if err := printOutput(cmd, out); err != nil {
+		return err
+	}
+	cmd.Println("Upgradeable status command ran successfully")
+	return nil
+}
+
+func upgradeableUpdateMinVersionRunE(cmd *cobra.Command, args []string) error {
+	version, err := parseUint256(args[0])
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	signer, err := loadSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	upgradeable, err := teleporterupgradeable.NewTeleporterUpgradeable(upgradeableAddress, client)
+	if err != nil {
+		return err
+	}
+	minVersion, err := upgradeable.GetMinTeleporterVersion(opts)
+	if err != nil {
+		return err
+	}
+	registryAddress, err := upgradeable.TeleporterRegistry(opts)
+	if err != nil {
+		return err
+	}
+	registry, err := teleporterregistry.NewTeleporterRegistryCaller(registryAddress, client)
+	if err != nil {
+		return err
+	}
+	latestVersion, err := registry.LatestVersion(opts)
+	if err != nil {
+		return err
+	}
+	if err := checkMinTeleporterVersion(version, minVersion, latestVersion); err != nil {
+		return err
+	}
+
+	data, err := packUpgradeable("updateMinTeleporterVersion", version)
+	if err != nil {
+		return err
+	}
+	if dryRunArg {
+		return simulateAndPrintTransaction(ctx, cmd, client, signer, upgradeableAddress, data, 0)
+	}
+	receipt, err := createAndSendTransaction(ctx, client, signer, upgradeableAddress, data, 0)
+	if err != nil {
+		return err
+	}
+	event, err := teleporterClient.EventFromLogs(
+		receipt.Logs, upgradeableAddress, upgradeable.ParseMinTeleporterVersionUpdated,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = printOutput(cmd, upgradeableMinVersionOutput{
+		TxHash:                  receipt.TxHash.Hex(),
+		OldMinTeleporterVersion: event.OldMinTeleporterVersion.String(),
+		NewMinTeleporterVersion: event.NewMinTeleporterVersion.String(),
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Println("Upgradeable update-min-version command ran successfully")
+	return nil
+}
+
+func upgradeablePauseRunE(cmd *cobra.Command, args []string) error {
+	teleporter, err := parseAddress(args[0])
+	if err != nil {
+		return err
+	}
+	if teleporter == (common.Address{}) {
+		return errZeroTeleporterAddress
+	}
+	ctx := context.Background()
+	signer, err := loadSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	upgradeable, err := teleporterupgradeable.NewTeleporterUpgradeable(upgradeableAddress, client)
+	if err != nil {
+		return err
+	}
+	paused, err := upgradeable.IsTeleporterAddressPaused(&bind.CallOpts{Context: ctx}, teleporter)
+	if err != nil {
+		return err
+	}
+	if paused {
+		return errTeleporterAddressPaused
+	}
+
+	data, err := packUpgradeable("pauseTeleporterAddress", teleporter)
+	if err != nil {
+		return err
+	}
+	if dryRunArg {
+		return simulateAndPrintTransaction(ctx, cmd, client, signer, upgradeableAddress, data, 0)
+	}
+	receipt, err := createAndSendTransaction(ctx, client, signer, upgradeableAddress, data, 0)
+	if err != nil {
+		return err
+	}
+	event, err := teleporterClient.EventFromLogs(
+		receipt.Logs, upgradeableAddress, upgradeable.ParseTeleporterAddressPaused,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = printOutput(cmd, upgradeablePauseOutput{
+		TxHash:            receipt.TxHash.Hex(),
+		TeleporterAddress: event.TeleporterAddress.Hex(),
+		Paused:            true,
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Println("Upgradeable pause command ran successfully")
+	return nil
+}
+
+func upgradeableUnpauseRunE(cmd *cobra.Command, args []string) error {
+	teleporter, err := parseAddress(args[0])
+	if err != nil {
+		return err
+	}
+	if teleporter == (common.Address{}) {
+		return errZeroTeleporterAddress
+	}
+	ctx := context.Background()
+	signer, err := loadSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	upgradeable, err := teleporterupgradeable.NewTeleporterUpgradeable(upgradeableAddress, client)
+	if err != nil {
+		return err
+	}
+	paused, err := upgradeable.IsTeleporterAddressPaused(&bind.CallOpts{Context: ctx}, teleporter)
+	if err != nil {
+		return err
+	}
+	if !paused {
+		return errTeleporterAddressActive
+	}
+
+	data, err := packUpgradeable("unpauseTeleporterAddress", teleporter)
+	if err != nil {
+		return err
+	}
+	if dryRunArg {
+		return simulateAndPrintTransaction(ctx, cmd, client, signer, upgradeableAddress, data, 0)
+	}
+	receipt, err := createAndSendTransaction(ctx, client, signer, upgradeableAddress, data, 0)
+	if err != nil {
+		return err
+	}
+	event, err := teleporterClient.EventFromLogs(
+		receipt.Logs, upgradeableAddress, upgradeable.ParseTeleporterAddressUnpaused,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = printOutput(cmd, upgradeablePauseOutput{
+		TxHash:            receipt.TxHash.Hex(),
+		TeleporterAddress: event.TeleporterAddress.Hex(),
+		Paused:            false,
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Println("Upgradeable unpause command ran successfully")
+	return nil
+}
+
+// checkMinTeleporterVersion checks that updateMinTeleporterVersion would accept the given version,
+// so that a transaction that would revert is not submitted.
+func checkMinTeleporterVersion(version, minVersion, latestVersion *big.Int) error {
+	if version.Cmp(minVersion) <= 0 {
+		return errMinVersionNotIncreased
+	}
+	if version.Cmp(latestVersion) > 0 {
+		return errMinVersionAboveLatest
+	}
+	return nil
+}
+
+// isTeleporterAddressAccepted reports whether a TeleporterUpgradeable contract receives messages
+// delivered by a Teleporter address. As in the contract, the version of the address is the value
+// returned by getVersionFromAddress, the highest version it is registered under.
+func isTeleporterAddressAccepted(paused bool, addressVersion, minVersion *big.Int) bool {
+	return !paused && addressVersion.Cmp(minVersion) >= 0
+}
+
+// packUpgradeable packs the calldata of the given TeleporterUpgradeable method.
+func packUpgradeable(method string, args ...interface{}) ([]byte, error) {
+	upgradeableABI, err := teleporterupgradeable.TeleporterUpgradeableMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return upgradeableABI.Pack(method, args...)
+}
+
+func init() {
+	rootCmd.AddCommand(upgradeableCmd)
+	upgradeableCmd.AddCommand(upgradeableStatusCmd, upgradeableUpdateMinVersionCmd, upgradeablePauseCmd,
+		upgradeableUnpauseCmd)
+	addContractClientFlags(upgradeableCmd, "contract-address", "c", "TeleporterUpgradeable contract address",
+		&upgradeableAddress)
+
+	for _, cmd := range []*cobra.Command{upgradeableUpdateMinVersionCmd, upgradeablePauseCmd, upgradeableUnpauseCmd} {
+		addKeyFlags(cmd)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeableCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"upgradeable", "status"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"upgradeable", "status", "--help"},
+			err:  nil,
+			out:  "Shows the minimum Teleporter version of the TeleporterUpgradeable contract",
+		},
+		{
+			name: "pause no args",
+			args: []string{"upgradeable", "pause"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestCheckMinTeleporterVersion(t *testing.T) {
+	var tests = []struct {
+		name    string
+		version int64
+		err     error
+	}{
+		{
+			name:    "valid",
+			version: 3,
+		},
+		{
+			name:    "latest version",
+			version: 4,
+		},
+		{
+			name:    "current minimum",
+			version: 2,
+			err:     errMinVersionNotIncreased,
+		},
+		{
+			name:    "above latest",
+			version: 5,
+			err:     errMinVersionAboveLatest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMinTeleporterVersion(big.NewInt(tt.version), big.NewInt(2), big.NewInt(4))
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestIsTeleporterAddressAccepted(t *testing.T) {
+	minVersion := big.NewInt(2)
+	require.True(t, isTeleporterAddressAccepted(false, big.NewInt(2), minVersion))
+	require.True(t, isTeleporterAddressAccepted(false, big.NewInt(3), minVersion))
+	require.False(t, isTeleporterAddressAccepted(false, big.NewInt(1), minVersion))
+	require.False(t, isTeleporterAddressAccepted(true, big.NewInt(3), minVersion))
+}
@@ -0,0 +1,91 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// parseID parses a blockchain or message ID given either as a CB58 string, or as 32 hex encoded bytes.
+func parseID(s string) (ids.ID, error) {
+	if id, err := ids.FromString(s); err == nil {
+		return id, nil
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return ids.Empty, fmt.Errorf("invalid ID %s", s)
+	}
+	id, err := ids.ToID(b)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("invalid ID %s: %w", s, err)
+	}
+	return id, nil
+}
+
+// parseAddress parses a hex encoded EVM address.
+func parseAddress(s string) (common.Address, error) {
+	if !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("invalid address %s", s)
+	}
+	return common.HexToAddress(s), nil
+}
+
+// parseAddresses parses a list of hex encoded EVM addresses.
+func parseAddresses(strs []string) ([]common.Address, error) {
+	addresses := make([]common.Address, 0, len(strs))
+	for _, s := range strs {
+		address, err := parseAddress(s)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// parseHash parses a hex encoded 32 byte hash, such as a transaction hash, with or without a 0x prefix.
+func parseHash(s string) (common.Hash, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(b) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid hash %s", s)
+	}
+	return common.BytesToHash(b), nil
+}
+
+// parseBigInt parses a base 10 integer string.
+func parseBigInt(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %s", s)
+	}
+	return n, nil
+}
+
+// parseUint256 parses a base 10 integer string that fits in a uint256. The ABI packer encodes
+// negative integers as two's complement, so they must be rejected before packing.
+func parseUint256(s string) (*big.Int, error) {
+	n, err := parseBigInt(s)
+	if err != nil {
+		return nil, err
+	}
+	if n.Sign() < 0 || n.BitLen() > 256 {
+		return nil, fmt.Errorf("invalid uint256 %s", s)
+	}
+	return n, nil
+}
+
+// parseHexBytes parses a hex encoded byte string, with or without a 0x prefix.
+func parseHexBytes(s string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex bytes %s: %w", s, err)
+	}
+	return b, nil
+}
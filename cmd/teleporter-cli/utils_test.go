@@ -0,0 +1,84 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUint256(t *testing.T) {
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	var tests = []struct {
+		name string
+		arg  string
+		out  *big.Int
+		err  string
+	}{
+		{
+			name: "zero",
+			arg:  "0",
+			out:  big.NewInt(0),
+		},
+		{
+			name: "max",
+			arg:  maxUint256.String(),
+			out:  maxUint256,
+		},
+		{
+			name: "negative",
+			arg:  "-1",
+			err:  "invalid uint256 -1",
+		},
+		{
+			name: "overflow",
+			arg:  new(big.Int).Add(maxUint256, big.NewInt(1)).String(),
+			err:  "invalid uint256",
+		},
+		{
+			name: "hex",
+			arg:  "0x1",
+			err:  "invalid integer 0x1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := parseUint256(tt.arg)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 0, tt.out.Cmp(n))
+		})
+	}
+
+	// The ABI packer silently encodes -1 as the maximum uint256, which parseUint256 prevents.
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: uint256Type}}.Pack(big.NewInt(-1))
+	require.NoError(t, err)
+	require.Equal(t, maxUint256.Bytes(), packed)
+}
+
+func TestParseHash(t *testing.T) {
+	hash := common.HexToHash("0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+
+	parsed, err := parseHash(hash.Hex())
+	require.NoError(t, err)
+	require.Equal(t, hash, parsed)
+
+	parsed, err = parseHash(hash.Hex()[2:])
+	require.NoError(t, err)
+	require.Equal(t, hash, parsed)
+
+	// Malformed and truncated hashes are rejected rather than padded.
+	for _, arg := range []string{"", "0x1234", "0xzz", hash.Hex() + "00"} {
+		_, err := parseHash(arg)
+		require.ErrorContains(t, err, "invalid hash")
+	}
+}
@@ -0,0 +1,156 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/params"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	warpPredicateArg    bool
+	validatorSetFileArg string
+	quorumNumeratorArg  uint64
+)
+
+var warpCmd = &cobra.Command{
+	Use:   "warp [--predicate] [--validators FILE] WARP_MESSAGE_BYTES",
+	Short: "Decodes hex encoded signed Warp message bytes",
+	Long: `Given the hex encoded bytes of a signed Warp message, this command will decode
+the unsigned message, the addressed call payload and the bit set signature, and
+then decode the Teleporter message carried in the payload. If --predicate is
+set, the bytes are expected to be the padded predicate of a
+receiveCrossChainMessage transaction's access list. If a validator set file is
+provided, the aggregate BLS signature is verified against it, and the signed
+weight is checked against the quorum. The file is the result of the P-Chain's
+platform.getValidatorsAt API, mapping node IDs to BLS public keys and weights.
+No network access is needed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: warpRunE,
+}
+
+// warpMessageOutput is the output schema of a signed Warp message.
+type warpMessageOutput struct {
+	WarpMessageID     string                  `json:"warpMessageID" yaml:"warpMessageID"`
+	NetworkID         uint32                  `json:"networkID" yaml:"networkID"`
+	SourceChainID     string                  `json:"sourceChainID" yaml:"sourceChainID"`
+	SourceAddress     string                  `json:"sourceAddress" yaml:"sourceAddress"`
+	Payload           string                  `json:"payload" yaml:"payload"`
+	Signers           string                  `json:"signers" yaml:"signers"`
+	SignerIndices     []int                   `json:"signerIndices" yaml:"signerIndices"`
+	NumSigners        int                     `json:"numSigners" yaml:"numSigners"`
+	Signature         string                  `json:"signature" yaml:"signature"`
+	TeleporterMessage map[string]interface{}  `json:"teleporterMessage,omitempty" yaml:"teleporterMessage,omitempty"`
+	Verification      *warpVerificationOutput `json:"verification,omitempty" yaml:"verification,omitempty"`
+}
+
+func warpRunE(cmd *cobra.Command, args []string) error {
+	b, err := parseHexBytes(args[0])
+	if err != nil {
+		return err
+	}
+
+	msg, err := parseSignedWarpMessage(b, warpPredicateArg)
+	if err != nil {
+		return err
+	}
+
+	out, err := newWarpMessageOutput(msg)
+	if err != nil {
+		return err
+	}
+	if validatorSetFileArg != "" {
+		vdrs, totalWeight, err := readValidatorSetFile(validatorSetFileArg)
+		if err != nil {
+			return err
+		}
+		out.Verification = verifyWarpSignature(msg, vdrs, totalWeight, quorumNumeratorArg)
+	}
+	if err := printOutput(cmd, out); err != nil {
+		return err
+	}
+	cmd.Println("Warp command ran successfully")
+	return nil
+}
+
+// parseSignedWarpMessage parses the bytes of a signed Warp message. If predicate is true, the bytes
+// are first unpadded as the predicate of a transaction's access list.
+func parseSignedWarpMessage(b []byte, predicate bool) (*avalancheWarp.Message, error) {
+	if predicate {
+		unpacked, err := predicateutils.UnpackPredicate(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack predicate: %w", err)
+		}
+		b = unpacked
+	}
+	return avalancheWarp.ParseMessage(b)
+}
+
+func newWarpMessageOutput(msg *avalancheWarp.Message) (*warpMessageOutput, error) {
+	signature, ok := msg.Signature.(*avalancheWarp.BitSetSignature)
+	if !ok {
+		return nil, fmt.Errorf("unsupported Warp signature type %T", msg.Signature)
+	}
+	numSigners, err := signature.NumSigners()
+	if err != nil {
+		return nil, err
+	}
+
+	addressedCall, err := warpPayload.ParseAddressedCall(msg.UnsignedMessage.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	warpMessageID := msg.UnsignedMessage.ID()
+	out := &warpMessageOutput{
+		WarpMessageID: hexString(warpMessageID[:]),
+		NetworkID:     msg.UnsignedMessage.NetworkID,
+		SourceChainID: msg.UnsignedMessage.SourceChainID.String(),
+		SourceAddress: common.BytesToAddress(addressedCall.SourceAddress).Hex(),
+		Payload:       hexString(addressedCall.Payload),
+		Signers:       hexString(signature.Signers),
+		SignerIndices: signerIndices(set.BitsFromBytes(signature.Signers)),
+		NumSigners:    numSigners,
+		Signature:     hexString(signature.Signature[:]),
+	}
+
+	// The payload of a Warp message not sent by Teleporter is still shown, but can't be decoded further.
+	teleporterMessage, err := teleportermessenger.UnpackTeleporterMessage(addressedCall.Payload)
+	if err != nil {
+		logger.Warn("Failed to decode Teleporter message from Warp payload", zap.Error(err))
+	} else {
+		out.TeleporterMessage = toOutputFields(teleporterMessage)
+	}
+	return out, nil
+}
+
+// signerIndices returns the indices of the validators in the canonical validator set that signed the message.
+func signerIndices(signers set.Bits) []int {
+	indices := []int{}
+	for i := 0; i < signers.BitLen(); i++ {
+		if signers.Contains(i) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+func init() {
+	rootCmd.AddCommand(warpCmd)
+	warpCmd.Flags().BoolVar(&warpPredicateArg, "predicate", false,
+		"Decode the bytes as a padded access list predicate")
+	warpCmd.Flags().StringVar(&validatorSetFileArg, "validators", "",
+		"Path to a validator set file to verify the signature against")
+	warpCmd.Flags().Uint64Var(&quorumNumeratorArg, "quorum-numerator", params.WarpDefaultQuorumNumerator,
+		"Numerator of the fraction of validator weight required to sign the message")
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarpCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"warp"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+		{
+			name: "help",
+			args: []string{"warp", "--help"},
+			err:  nil,
+			out:  "Given the hex encoded bytes of a signed Warp message",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+// newTestSignedWarpMessage returns a Warp message with the given payload, sent from sourceAddress
+// and signed by the validators at indices 0 and 2.
+func newTestSignedWarpMessage(t *testing.T, sourceAddress common.Address, payload []byte) *avalancheWarp.Message {
+	addressedCall, err := warpPayload.NewAddressedCall(sourceAddress.Bytes(), payload)
+	require.NoError(t, err)
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(1, ids.ID{4, 5, 6}, addressedCall.Bytes())
+	require.NoError(t, err)
+	signature := &avalancheWarp.BitSetSignature{Signers: set.NewBits(0, 2).Bytes()}
+	signature.Signature[0] = 1
+	msg, err := avalancheWarp.NewMessage(unsignedMsg, signature)
+	require.NoError(t, err)
+	return msg
+}
+
+func TestParseSignedWarpMessage(t *testing.T) {
+	msg := newTestSignedWarpMessage(t, common.HexToAddress("0x1"), []byte{1, 2, 3})
+	predicate := predicateutils.PackPredicate(msg.Bytes())
+
+	var tests = []struct {
+		name      string
+		bytes     []byte
+		predicate bool
+		err       string
+	}{
+		{
+			name:  "message",
+			bytes: msg.Bytes(),
+		},
+		{
+			name:      "predicate",
+			bytes:     predicate,
+			predicate: true,
+		},
+		{
+			name:      "message as predicate",
+			bytes:     msg.Bytes(),
+			predicate: true,
+			err:       "failed to unpack predicate",
+		},
+		{
+			name:  "predicate as message",
+			bytes: predicate,
+			err:   "trailing buffer space",
+		},
+		{
+			name:  "truncated message",
+			bytes: msg.Bytes()[:len(msg.Bytes())-1],
+			err:   "insufficient length",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseSignedWarpMessage(tt.bytes, tt.predicate)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, msg.Bytes(), parsed.Bytes())
+		})
+	}
+}
+
+func TestNewWarpMessageOutput(t *testing.T) {
+	setTestGlobals(t, common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"))
+
+	messageBytes, err := teleportermessenger.PackTeleporterMessage(teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		OriginSenderAddress:     common.HexToAddress("0x2"),
+		DestinationBlockchainID: ids.ID{1, 2, 3},
+		DestinationAddress:      common.HexToAddress("0x3"),
+		RequiredGasLimit:        big.NewInt(100_000),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 []byte{1, 2},
+	})
+	require.NoError(t, err)
+	msg := newTestSignedWarpMessage(t, teleporterAddress, messageBytes)
+
+	out, err := newWarpMessageOutput(msg)
+	require.NoError(t, err)
+	warpMessageID := msg.UnsignedMessage.ID()
+	require.Equal(t, hexString(warpMessageID[:]), out.WarpMessageID)
+	require.Equal(t, uint32(1), out.NetworkID)
+	// Blockchain IDs are CB58 encoded, as in the nested Teleporter message.
+	require.Equal(t, msg.UnsignedMessage.SourceChainID.String(), out.SourceChainID)
+	require.Equal(t, teleporterAddress.Hex(), out.SourceAddress)
+	require.Equal(t, hexString(messageBytes), out.Payload)
+	require.Equal(t, "0x05", out.Signers)
+	require.Equal(t, []int{0, 2}, out.SignerIndices)
+	require.Equal(t, 2, out.NumSigners)
+	signature := msg.Signature.(*avalancheWarp.BitSetSignature).Signature
+	require.Equal(t, hexString(signature[:]), out.Signature)
+	require.Equal(t, "1", fmt.Sprint(out.TeleporterMessage["messageNonce"]))
+	require.Equal(t, common.HexToAddress("0x2").Hex(), out.TeleporterMessage["originSenderAddress"])
+	require.Nil(t, out.Verification)
+
+	// The payload of a Warp message not sent by Teleporter is output without a Teleporter message.
+	out, err = newWarpMessageOutput(newTestSignedWarpMessage(t, common.HexToAddress("0x1"), []byte{1, 2, 3}))
+	require.NoError(t, err)
+	require.Equal(t, "0x010203", out.Payload)
+	require.Nil(t, out.TeleporterMessage)
+
+	// A payload that is not an addressed call can't be decoded.
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(1, ids.ID{4, 5, 6}, []byte{1, 2, 3})
+	require.NoError(t, err)
+	msg, err = avalancheWarp.NewMessage(unsignedMsg, &avalancheWarp.BitSetSignature{})
+	require.NoError(t, err)
+	_, err = newWarpMessageOutput(msg)
+	require.Error(t, err)
+}
@@ -0,0 +1,185 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	errInvalidSignerBitSet = errors.New("signer bit set is not canonically encoded")
+	errSignersOutOfRange   = errors.New("signer bit set references more validators than are in the validator set")
+)
+
+// validatorSetFile is the format of a validator set file, matching the result of the P-Chain's
+// platform.getValidatorsAt API.
+type validatorSetFile struct {
+	Validators map[string]validatorFileEntry `json:"validators"`
+}
+
+type validatorFileEntry struct {
+	PublicKey string         `json:"publicKey"`
+	Weight    avajson.Uint64 `json:"weight"`
+}
+
+// warpVerificationOutput is the output schema of the BLS signature verification of a Warp message.
+type warpVerificationOutput struct {
+	SignatureValid    bool   `json:"signatureValid" yaml:"signatureValid"`
+	SignedWeight      uint64 `json:"signedWeight" yaml:"signedWeight"`
+	TotalWeight       uint64 `json:"totalWeight" yaml:"totalWeight"`
+	QuorumNumerator   uint64 `json:"quorumNumerator" yaml:"quorumNumerator"`
+	QuorumDenominator uint64 `json:"quorumDenominator" yaml:"quorumDenominator"`
+	MeetsQuorum       bool   `json:"meetsQuorum" yaml:"meetsQuorum"`
+	Verified          bool   `json:"verified" yaml:"verified"`
+	// Error describes why the signature could not be checked, if applicable.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// readValidatorSetFile reads the validator set file at the given path, and returns the canonical validator
+// set used to verify Warp signatures along with the total weight of all validators.
+func readValidatorSetFile(path string) ([]*avalancheWarp.Validator, uint64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	var file validatorSetFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse validator set file: %w", err)
+	}
+	return canonicalValidatorSet(file)
+}
+
+// canonicalValidatorSet mirrors avalancheWarp.GetCanonicalValidatorSet for a validator set read from a file.
+// Validators without a BLS public key contribute to the total weight but can't sign. Validators sharing a
+// public key are merged, and the resulting set is sorted by public key.
+func canonicalValidatorSet(file validatorSetFile) ([]*avalancheWarp.Validator, uint64, error) {
+	var (
+		totalWeight uint64
+		vdrs        = make(map[string]*avalancheWarp.Validator)
+	)
+	for nodeIDStr, entry := range file.Validators {
+		nodeID, err := ids.NodeIDFromString(nodeIDStr)
+		if err != nil {
+			return nil, 0, err
+		}
+		weight := uint64(entry.Weight)
+		totalWeight, err = safemath.Add64(totalWeight, weight)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if entry.PublicKey == "" {
+			continue
+		}
+		pkBytes, err := hexutil.Decode(ensureHexPrefix(entry.PublicKey))
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid public key of %s: %w", nodeID, err)
+		}
+		pk, err := bls.PublicKeyFromBytes(pkBytes)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid public key of %s: %w", nodeID, err)
+		}
+		// Validators are keyed and ordered by their compressed public key bytes.
+		pkBytes = bls.PublicKeyToBytes(pk)
+
+		vdr, ok := vdrs[string(pkBytes)]
+		if !ok {
+			vdr = &avalancheWarp.Validator{
+				PublicKey:      pk,
+				PublicKeyBytes: pkBytes,
+			}
+			vdrs[string(pkBytes)] = vdr
+		}
+		vdr.Weight += weight
+		vdr.NodeIDs = append(vdr.NodeIDs, nodeID)
+	}
+
+	vdrList := make([]*avalancheWarp.Validator, 0, len(vdrs))
+	for _, vdr := range vdrs {
+		vdrList = append(vdrList, vdr)
+	}
+	utils.Sort(vdrList)
+	return vdrList, totalWeight, nil
+}
+
+// verifyWarpSignature checks the bit set signature of msg against the canonical validator set, mirroring
+// the checks of avalancheWarp.BitSetSignature.Verify, but reporting the signed weight rather than failing
+// on the first error.
+func verifyWarpSignature(
+	msg *avalancheWarp.Message,
+	vdrs []*avalancheWarp.Validator,
+	totalWeight uint64,
+	quorumNumerator uint64,
+) *warpVerificationOutput {
+	out := &warpVerificationOutput{
+		TotalWeight:       totalWeight,
+		QuorumNumerator:   quorumNumerator,
+		QuorumDenominator: params.WarpQuorumDenominator,
+	}
+
+	signature, ok := msg.Signature.(*avalancheWarp.BitSetSignature)
+	if !ok {
+		out.Error = fmt.Sprintf("unsupported Warp signature type %T", msg.Signature)
+		return out
+	}
+
+	signerIndices := set.BitsFromBytes(signature.Signers)
+	if len(signerIndices.Bytes()) != len(signature.Signers) {
+		out.Error = errInvalidSignerBitSet.Error()
+		return out
+	}
+	if signerIndices.BitLen() > len(vdrs) {
+		out.Error = errSignersOutOfRange.Error()
+		return out
+	}
+
+	signers, err := avalancheWarp.FilterValidators(signerIndices, vdrs)
+	if err != nil {
+		out.Error = err.Error()
+		return out
+	}
+	out.SignedWeight, err = avalancheWarp.SumWeight(signers)
+	if err != nil {
+		out.Error = err.Error()
+		return out
+	}
+	out.MeetsQuorum = avalancheWarp.VerifyWeight(
+		out.SignedWeight, totalWeight, quorumNumerator, params.WarpQuorumDenominator,
+	) == nil
+
+	aggregatePublicKey, err := avalancheWarp.AggregatePublicKeys(signers)
+	if err != nil {
+		out.Error = err.Error()
+		return out
+	}
+	sig, err := bls.SignatureFromBytes(signature.Signature[:])
+	if err != nil {
+		out.Error = err.Error()
+		return out
+	}
+	out.SignatureValid = bls.Verify(aggregatePublicKey, sig, msg.UnsignedMessage.Bytes())
+	out.Verified = out.SignatureValid && out.MeetsQuorum
+	return out
+}
+
+func ensureHexPrefix(s string) string {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return s
+	}
+	return "0x" + s
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyWarpSignature(t *testing.T) {
+	// Four validators of equal weight, the last without a BLS public key.
+	var (
+		secretKeys []*bls.SecretKey
+		file       = validatorSetFile{Validators: make(map[string]validatorFileEntry)}
+	)
+	for i := 0; i < 4; i++ {
+		entry := validatorFileEntry{Weight: avajson.Uint64(100)}
+		if i < 3 {
+			sk, err := bls.NewSecretKey()
+			require.NoError(t, err)
+			secretKeys = append(secretKeys, sk)
+			entry.PublicKey = hexutil.Encode(bls.PublicKeyToBytes(bls.PublicFromSecretKey(sk)))
+		}
+		file.Validators[ids.GenerateTestNodeID().String()] = entry
+	}
+	vdrs, totalWeight, err := canonicalValidatorSet(file)
+	require.NoError(t, err)
+	require.Len(t, vdrs, 3)
+	require.Equal(t, uint64(400), totalWeight)
+
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(1, ids.GenerateTestID(), []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	// signMessage signs the message with the first numSigners secret keys.
+	signMessage := func(numSigners int) *avalancheWarp.Message {
+		signers := set.NewBits()
+		var signatures []*bls.Signature
+		for _, sk := range secretKeys[:numSigners] {
+			pkBytes := bls.PublicKeyToBytes(bls.PublicFromSecretKey(sk))
+			for i, vdr := range vdrs {
+				if bytes.Equal(vdr.PublicKeyBytes, pkBytes) {
+					signers.Add(i)
+				}
+			}
+			signatures = append(signatures, bls.Sign(sk, unsignedMsg.Bytes()))
+		}
+		aggregate, err := bls.AggregateSignatures(signatures)
+		require.NoError(t, err)
+
+		signature := &avalancheWarp.BitSetSignature{Signers: signers.Bytes()}
+		copy(signature.Signature[:], bls.SignatureToBytes(aggregate))
+		msg, err := avalancheWarp.NewMessage(unsignedMsg, signature)
+		require.NoError(t, err)
+		return msg
+	}
+
+	var tests = []struct {
+		name           string
+		numSigners     int
+		signedWeight   uint64
+		signatureValid bool
+		meetsQuorum    bool
+	}{
+		{
+			name:           "meets quorum",
+			numSigners:     3,
+			signedWeight:   300,
+			signatureValid: true,
+			meetsQuorum:    true,
+		},
+		{
+			name:           "insufficient weight",
+			numSigners:     2,
+			signedWeight:   200,
+			signatureValid: true,
+			meetsQuorum:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := verifyWarpSignature(signMessage(tt.numSigners), vdrs, totalWeight, params.WarpDefaultQuorumNumerator)
+			require.Empty(t, out.Error)
+			require.Equal(t, tt.signedWeight, out.SignedWeight)
+			require.Equal(t, tt.signatureValid, out.SignatureValid)
+			require.Equal(t, tt.meetsQuorum, out.MeetsQuorum)
+			require.Equal(t, tt.signatureValid && tt.meetsQuorum, out.Verified)
+		})
+	}
+}
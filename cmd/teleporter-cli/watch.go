@@ -0,0 +1,307 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/x/warp"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterUtils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const watchResubscribeDelay = 2 * time.Second
+
+var (
+	watchEventsArg                  []string
+	watchMessageIDArg               string
+	watchSourceBlockchainIDArg      string
+	watchDestinationBlockchainIDArg string
+	watchOriginSenderArg            string
+	watchFromBlockArg               uint64
+
+	errWebsocketRequired   = errors.New("watch requires a websocket RPC endpoint, i.e. ws:// or wss://")
+	errWarpMessageIDFilter = errors.New("--message-id cannot be used to filter SendWarpMessage events")
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch --rpc WS_URL --teleporter-address CONTRACT_ADDRESS [--from-block BLOCK]",
+	Short: "Streams Teleporter logs as they are emitted",
+	Long: `Subscribes to the logs emitted by the Teleporter contract, and the Warp
+messages sent by the Teleporter contract, over a websocket RPC endpoint and
+prints each log as it arrives. Logs can be filtered by event type, message ID,
+source and destination blockchain ID, and origin sender address. If the
+subscription is dropped the command reconnects and resumes from the last log it
+printed, so that no logs are missed. Logs emitted since an earlier block can be
+included with --from-block. With --chain, the chain's websocket endpoint is
+used.`,
+	Args:        cobra.NoArgs,
+	RunE:        watchRunE,
+	Annotations: map[string]string{chainURLAnnotation: chainURLWebsocket},
+}
+
+// watchFilter selects which decoded logs are printed. Empty fields match all logs.
+type watchFilter struct {
+	events                  map[string]bool
+	messageID               ids.ID
+	sourceBlockchainID      ids.ID
+	destinationBlockchainID ids.ID
+	originSenderAddress     common.Address
+}
+
+// logPosition identifies the position of a log within the chain.
+type logPosition struct {
+	blockNumber uint64
+	index       uint
+}
+
+func (p logPosition) after(other logPosition) bool {
+	if p.blockNumber != other.blockNumber {
+		return p.blockNumber > other.blockNumber
+	}
+	return p.index > other.index
+}
+
+func watchRunE(cmd *cobra.Command, args []string) error {
+	if !strings.HasPrefix(rpcEndpoint, "ws://") && !strings.HasPrefix(rpcEndpoint, "wss://") {
+		return errWebsocketRequired
+	}
+	filter, err := newWatchFilter()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	messenger, err := teleportermessenger.NewTeleporterMessengerCaller(teleporterAddress, client)
+	if err != nil {
+		return err
+	}
+	blockchainID, err := messenger.BlockchainID(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return err
+	}
+
+	fromBlock, err := watchStartBlock(ctx, cmd.Flags().Changed("from-block"))
+	if err != nil {
+		return err
+	}
+
+	// last is the position of the last log handled, or nil if no log has been handled yet.
+	var last *logPosition
+	for {
+		err := watchLogs(ctx, cmd, filter, blockchainID, fromBlock, &last)
+		if ctx.Err() != nil {
+			return nil
+		}
+		logger.Warn("Log subscription failed, resubscribing", zap.Error(err))
+		if last != nil {
+			fromBlock = last.blockNumber
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(watchResubscribeDelay):
+		}
+		if err := redialClient(); err != nil {
+			logger.Warn("Failed to reconnect to RPC endpoint", zap.Error(err))
+		}
+	}
+}
+
+// watchLogs subscribes to new logs, and prints the logs emitted since fromBlock followed by new logs until
+// the subscription fails or the context is cancelled. last is updated with each log handled, and logs
+// at or before last are skipped so that logs are not printed twice across resubscriptions.
+func watchLogs(
+	ctx context.Context,
+	cmd *cobra.Command,
+	filter *watchFilter,
+	blockchainID ids.ID,
+	fromBlock uint64,
+	last **logPosition,
+) error {
+	// Subscribe before querying past logs so that no logs are emitted between the two.
+	logs := make(chan types.Log)
+	sub, err := client.SubscribeFilterLogs(ctx, interfaces.FilterQuery{
+		Addresses: []common.Address{teleporterAddress, warp.ContractAddress},
+	}, logs)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	handle := func(log types.Log) error {
+		position := logPosition{blockNumber: log.BlockNumber, index: log.Index}
+		if log.Removed || (*last != nil && !position.after(**last)) {
+			return nil
+		}
+		*last = &position
+		return printWatchedLog(cmd, filter, blockchainID, &log)
+	}
+
+	latest, err := client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	pastLogs, err := teleporterUtils.FilterLogs(ctx, client, interfaces.FilterQuery{
+		Addresses: []common.Address{teleporterAddress, warp.ContractAddress},
+	}, fromBlock, latest, teleporterUtils.DefaultFilterLogsChunkSize)
+	if err != nil {
+		return err
+	}
+	for _, log := range pastLogs {
+		if err := handle(log); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return err
+		case log := <-logs:
+			if err := handle(log); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func printWatchedLog(cmd *cobra.Command, filter *watchFilter, blockchainID ids.ID, log *types.Log) error {
+	decoded, err := decodeLog(log)
+	if err != nil {
+		// A log that can't be decoded should not stop the stream.
+		logger.Warn("Failed to decode log",
+			zap.String("txHash", log.TxHash.Hex()),
+			zap.Uint("logIndex", log.Index),
+			zap.Error(err))
+		return nil
+	}
+	if decoded == nil {
+		return nil
+	}
+	decoded.setLocalBlockchainID(blockchainID)
+	if !filter.matches(decoded) {
+		return nil
+	}
+	return printOutput(cmd, decoded.Output)
+}
+
+func redialClient() error {
+	c, err := ethclient.Dial(rpcEndpoint)
+	if err != nil {
+		return err
+	}
+	client.Close()
+	client = c
+	return nil
+}
+
+// watchStartBlock returns the block to print logs from. Logs are printed from the latest block unless
+// --from-block is set, including to block 0, so that logs emitted while resubscribing are not missed
+// even if no log has been handled yet.
+func watchStartBlock(ctx context.Context, fromBlockSet bool) (uint64, error) {
+	if fromBlockSet {
+		return watchFromBlockArg, nil
+	}
+	return client.BlockNumber(ctx)
+}
+
+// newWatchFilter returns the filter selected by the flags. The --event flag accepts the Teleporter
+// event names and the SendWarpMessage event of the Warp messages sent by the Teleporter contract.
+func newWatchFilter() (*watchFilter, error) {
+	filter := &watchFilter{}
+	if len(watchEventsArg) > 0 {
+		filter.events = make(map[string]bool)
+	}
+	for _, arg := range watchEventsArg {
+		if strings.EqualFold(arg, sendWarpMessageEventName) {
+			filter.events[sendWarpMessageEventName] = true
+			continue
+		}
+		event, err := teleportermessenger.ToEvent(arg)
+		if err != nil {
+			return nil, err
+		}
+		filter.events[event.String()] = true
+	}
+	if watchMessageIDArg != "" && filter.events[sendWarpMessageEventName] {
+		return nil, errWarpMessageIDFilter
+	}
+
+	var err error
+	for _, id := range []struct {
+		arg string
+		out *ids.ID
+	}{
+		{watchMessageIDArg, &filter.messageID},
+		{watchSourceBlockchainIDArg, &filter.sourceBlockchainID},
+		{watchDestinationBlockchainIDArg, &filter.destinationBlockchainID},
+	} {
+		if id.arg == "" {
+			continue
+		}
+		if *id.out, err = parseID(id.arg); err != nil {
+			return nil, err
+		}
+	}
+
+	if watchOriginSenderArg != "" {
+		if filter.originSenderAddress, err = parseAddress(watchOriginSenderArg); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+func (f *watchFilter) matches(decoded *decodedLog) bool {
+	if f.events != nil && !f.events[decoded.Output.Event] {
+		return false
+	}
+	if f.messageID != ids.Empty && decoded.MessageID != f.messageID {
+		return false
+	}
+	if f.sourceBlockchainID != ids.Empty && decoded.SourceBlockchainID != f.sourceBlockchainID {
+		return false
+	}
+	if f.destinationBlockchainID != ids.Empty && decoded.DestinationBlockchainID != f.destinationBlockchainID {
+		return false
+	}
+	if f.originSenderAddress != (common.Address{}) && decoded.OriginSenderAddress != f.originSenderAddress {
+		return false
+	}
+	return true
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	addClientFlags(watchCmd)
+	watchCmd.Flags().StringSliceVar(&watchEventsArg, "event", []string{},
+		"Event types to print, e.g. SendCrossChainMessage or SendWarpMessage. All events are printed if empty")
+	watchCmd.Flags().StringVar(&watchMessageIDArg, "message-id", "", "Message ID to filter by, CB58 or hex encoded")
+	watchCmd.Flags().StringVar(&watchSourceBlockchainIDArg, "source-blockchain-id", "",
+		"Source blockchain ID to filter by, CB58 or hex encoded")
+	watchCmd.Flags().StringVar(&watchDestinationBlockchainIDArg, "destination-blockchain-id", "",
+		"Destination blockchain ID to filter by, CB58 or hex encoded")
+	watchCmd.Flags().StringVar(&watchOriginSenderArg, "origin-sender", "", "Origin sender address to filter by")
+	watchCmd.Flags().Uint64Var(&watchFromBlockArg, "from-block", 0,
+		"Print the logs emitted since this block, e.g. the last block seen by a previous run. Defaults to the latest block")
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"watch"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"watch", "--help"},
+			err:  nil,
+			out:  "Subscribes to the logs emitted by the Teleporter contract, and the Warp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestWatchFilterMatches(t *testing.T) {
+	messageID := ids.ID{1}
+	sourceBlockchainID := ids.ID{2}
+	destinationBlockchainID := ids.ID{3}
+	originSenderAddress := common.Address{4}
+	decoded := &decodedLog{
+		Output:                  logOutput{Event: "SendCrossChainMessage"},
+		MessageID:               messageID,
+		SourceBlockchainID:      sourceBlockchainID,
+		DestinationBlockchainID: destinationBlockchainID,
+		OriginSenderAddress:     originSenderAddress,
+	}
+
+	var tests = []struct {
+		name    string
+		filter  watchFilter
+		matches bool
+	}{
+		{
+			name:    "empty filter",
+			matches: true,
+		},
+		{
+			name:    "matching event",
+			filter:  watchFilter{events: map[string]bool{"SendCrossChainMessage": true, "MessageExecuted": true}},
+			matches: true,
+		},
+		{
+			name:   "other event",
+			filter: watchFilter{events: map[string]bool{"MessageExecuted": true}},
+		},
+		{
+			name:    "matching message ID",
+			filter:  watchFilter{messageID: messageID},
+			matches: true,
+		},
+		{
+			name:   "other message ID",
+			filter: watchFilter{messageID: ids.ID{5}},
+		},
+		{
+			name:    "matching source blockchain ID",
+			filter:  watchFilter{sourceBlockchainID: sourceBlockchainID},
+			matches: true,
+		},
+		{
+			name:   "other source blockchain ID",
+			filter: watchFilter{sourceBlockchainID: destinationBlockchainID},
+		},
+		{
+			name:    "matching destination blockchain ID",
+			filter:  watchFilter{destinationBlockchainID: destinationBlockchainID},
+			matches: true,
+		},
+		{
+			name:   "other destination blockchain ID",
+			filter: watchFilter{destinationBlockchainID: sourceBlockchainID},
+		},
+		{
+			name:    "matching origin sender",
+			filter:  watchFilter{originSenderAddress: originSenderAddress},
+			matches: true,
+		},
+		{
+			name:   "other origin sender",
+			filter: watchFilter{originSenderAddress: common.Address{5}},
+		},
+		{
+			name: "all fields matching",
+			filter: watchFilter{
+				events:                  map[string]bool{"SendCrossChainMessage": true},
+				messageID:               messageID,
+				sourceBlockchainID:      sourceBlockchainID,
+				destinationBlockchainID: destinationBlockchainID,
+				originSenderAddress:     originSenderAddress,
+			},
+			matches: true,
+		},
+		{
+			name: "one field not matching",
+			filter: watchFilter{
+				events:                  map[string]bool{"SendCrossChainMessage": true},
+				messageID:               messageID,
+				sourceBlockchainID:      sourceBlockchainID,
+				destinationBlockchainID: destinationBlockchainID,
+				originSenderAddress:     common.Address{5},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.matches, tt.filter.matches(decoded))
+		})
+	}
+
+	// A log without a message ID, e.g. a Warp log, doesn't match a message ID filter.
+	filter := watchFilter{messageID: messageID}
+	require.False(t, filter.matches(&decodedLog{Output: logOutput{Event: sendWarpMessageEventName}}))
+}
+
+func TestNewWatchFilter(t *testing.T) {
+	previousEvents, previousMessageID := watchEventsArg, watchMessageIDArg
+	t.Cleanup(func() {
+		watchEventsArg, watchMessageIDArg = previousEvents, previousMessageID
+	})
+	watchMessageIDArg = ""
+
+	// The SendWarpMessage logs watched along with the Teleporter logs can be selected by name.
+	watchEventsArg = []string{"sendWarpMessage", "MessageExecuted"}
+	filter, err := newWatchFilter()
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{sendWarpMessageEventName: true, "MessageExecuted": true}, filter.events)
+	require.True(t, filter.matches(&decodedLog{Output: logOutput{Event: sendWarpMessageEventName}}))
+
+	// Selecting only Teleporter events excludes the SendWarpMessage logs.
+	watchEventsArg = []string{"MessageExecuted"}
+	filter, err = newWatchFilter()
+	require.NoError(t, err)
+	require.False(t, filter.matches(&decodedLog{Output: logOutput{Event: sendWarpMessageEventName}}))
+
+	watchEventsArg = []string{"NotAnEvent"}
+	_, err = newWatchFilter()
+	require.Error(t, err)
+
+	// SendWarpMessage logs carry no message ID, so filtering them by message ID is rejected.
+	watchMessageIDArg = common.Hash{}.Hex()
+	watchEventsArg = []string{"SendWarpMessage", "MessageExecuted"}
+	_, err = newWatchFilter()
+	require.ErrorIs(t, err, errWarpMessageIDFilter)
+
+	watchEventsArg = []string{"MessageExecuted"}
+	_, err = newWatchFilter()
+	require.NoError(t, err)
+}
+
+func TestWatchStartBlock(t *testing.T) {
+	previousClient, previousFromBlock := client, watchFromBlockArg
+	t.Cleanup(func() {
+		client, watchFromBlockArg = previousClient, previousFromBlock
+	})
+	client = &fakeLogsClient{latest: 10}
+
+	// The latest block is used unless --from-block is set, including to the genesis block.
+	watchFromBlockArg = 0
+	fromBlock, err := watchStartBlock(context.Background(), false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), fromBlock)
+
+	fromBlock, err = watchStartBlock(context.Background(), true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), fromBlock)
+
+	watchFromBlockArg = 5
+	fromBlock, err = watchStartBlock(context.Background(), true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), fromBlock)
+}
+
+// ethClient names the embedded interface of fakeLogsClient, since a field named Client would
+// conflict with the Client method of ethclient.Client.
+type ethClient = ethclient.Client
+
+// fakeLogsClient is an ethclient.Client that returns the pastLogs matching the query from FilterLogs,
+// and whose subscriptions deliver newLogs followed by an error.
+type fakeLogsClient struct {
+	ethClient
+	latest   uint64
+	pastLogs []types.Log
+	newLogs  []types.Log
+}
+
+func (c *fakeLogsClient) BlockNumber(context.Context) (uint64, error) {
+	return c.latest, nil
+}
+
+func (c *fakeLogsClient) FilterLogs(_ context.Context, query interfaces.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for _, log := range c.pastLogs {
+		if log.BlockNumber >= query.FromBlock.Uint64() && log.BlockNumber <= query.ToBlock.Uint64() &&
+			matchesFilterQuery(log, query) {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+// matchesFilterQuery reports whether the log matches the addresses and topics of the query.
+func matchesFilterQuery(log types.Log, query interfaces.FilterQuery) bool {
+	if len(query.Addresses) > 0 && !containsAddress(query.Addresses, log.Address) {
+		return false
+	}
+	for i, topics := range query.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		if i >= len(log.Topics) || !containsHash(topics, log.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
+func containsHash(hashes []common.Hash, hash common.Hash) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *fakeLogsClient) SubscribeFilterLogs(
+	ctx context.Context,
+	_ interfaces.FilterQuery,
+	ch chan<- types.Log,
+) (interfaces.Subscription, error) {
+	sub := &fakeSubscription{err: make(chan error, 1)}
+	go func() {
+		for _, log := range c.newLogs {
+			select {
+			case ch <- log:
+			case <-ctx.Done():
+				return
+			}
+		}
+		sub.err <- errSubscriptionDropped
+	}()
+	return sub, nil
+}
+
+var errSubscriptionDropped = errors.New("subscription dropped")
+
+type fakeSubscription struct {
+	err chan error
+}
+
+func (s *fakeSubscription) Unsubscribe() {}
+
+func (s *fakeSubscription) Err() <-chan error {
+	return s.err
+}
+
+func TestWatchLogsResubscribe(t *testing.T) {
+	setTestGlobals(t, common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"))
+	previousClient, previousOutputFormat := client, outputFormat
+	t.Cleanup(func() {
+		client, outputFormat = previousClient, previousOutputFormat
+	})
+	outputFormat = outputJSON
+
+	// messageExecutedLog returns a MessageExecuted log at the given position.
+	messageExecutedLog := func(blockNumber uint64, index uint) types.Log {
+		return types.Log{
+			Address: teleporterAddress,
+			Topics: []common.Hash{
+				teleporterABI.Events["MessageExecuted"].ID,
+				{byte(blockNumber), byte(index)},
+				{},
+			},
+			BlockNumber: blockNumber,
+			Index:       index,
+		}
+	}
+	removedLog := messageExecutedLog(4, 0)
+	removedLog.Removed = true
+
+	// watch runs watchLogs once against the fake client, and returns the positions of the printed logs.
+	watch := func(fakeClient *fakeLogsClient, fromBlock uint64, last **logPosition) []logPosition {
+		client = fakeClient
+		cmd := &cobra.Command{}
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		err := watchLogs(context.Background(), cmd, &watchFilter{}, ids.Empty, fromBlock, last)
+		require.ErrorIs(t, err, errSubscriptionDropped)
+
+		var positions []logPosition
+		dec := json.NewDecoder(buf)
+		for {
+			var out logOutput
+			err := dec.Decode(&out)
+			if errors.Is(err, io.EOF) {
+				return positions
+			}
+			require.NoError(t, err)
+			require.Equal(t, "MessageExecuted", out.Event)
+			positions = append(positions, logPosition{blockNumber: *out.BlockNumber, index: *out.LogIndex})
+		}
+	}
+
+	var last *logPosition
+	printed := watch(&fakeLogsClient{
+		latest:   2,
+		pastLogs: []types.Log{messageExecutedLog(1, 0), messageExecutedLog(2, 0)},
+		newLogs:  []types.Log{messageExecutedLog(2, 1)},
+	}, 1, &last)
+	require.Equal(t, []logPosition{{1, 0}, {2, 0}, {2, 1}}, printed)
+	require.Equal(t, &logPosition{2, 1}, last)
+
+	// After resubscribing from the block of the last log, the logs at or before the last log are
+	// skipped, whether they are returned as past logs or delivered by the new subscription.
+	printed = watch(&fakeLogsClient{
+		latest:   3,
+		pastLogs: []types.Log{messageExecutedLog(2, 0), messageExecutedLog(2, 1), messageExecutedLog(3, 0)},
+		newLogs:  []types.Log{messageExecutedLog(3, 0), messageExecutedLog(3, 1), removedLog, messageExecutedLog(4, 1)},
+	}, last.blockNumber, &last)
+	require.Equal(t, []logPosition{{3, 0}, {3, 1}, {4, 1}}, printed)
+	require.Equal(t, &logPosition{4, 1}, last)
+}
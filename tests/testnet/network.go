@@ -4,20 +4,18 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"errors"
-	"math/big"
 	"os"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ava-labs/avalanchego/ids"
-	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/ethclient"
-	subnetevminterfaces "github.com/ava-labs/subnet-evm/interfaces"
 	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
 	"github.com/ava-labs/teleporter/tests/interfaces"
 	"github.com/ava-labs/teleporter/tests/utils"
+	teleporterUtils "github.com/ava-labs/teleporter/utils/teleporter-utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 
@@ -38,7 +36,6 @@ const (
 	userAddress                     = "user_address"
 	userPrivateKey                  = "user_private_key"
 
-	receiveCrossChainMessageEventName      = "ReceiveCrossChainMessage"
 	receiveCrossChainMessageLookBackBlocks = 500
 
 	privateKeyHexLength = 64
@@ -220,22 +217,6 @@ func (n *testNetwork) RelayMessage(ctx context.Context,
 	return receipt
 }
 
-func (n *testNetwork) checkMessageDelivered(
-	sourceBlockchainID ids.ID,
-	destination interfaces.SubnetTestInfo,
-	teleporterMessageID ids.ID) (bool, error) {
-	destinationTeleporterMessenger, err := teleportermessenger.NewTeleporterMessenger(
-		n.teleporterContractAddress,
-		destination.RPCClient)
-	if err != nil {
-		return false, err
-	}
-
-	return destinationTeleporterMessenger.MessageReceived(
-		&bind.CallOpts{}, teleporterMessageID,
-	)
-}
-
 func (n *testNetwork) getMessageDeliveryTransactionReceipt(
 	ctx context.Context,
 	sourceBlockchainID ids.ID,
@@ -248,47 +229,29 @@ func (n *testNetwork) getMessageDeliveryTransactionReceipt(
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
 		}
-		delivered, err = n.checkMessageDelivered(sourceBlockchainID, destination, teleporterMessageID)
+		delivered, err = teleporterUtils.CheckMessageDelivered(
+			ctx, destination.RPCClient, n.teleporterContractAddress, teleporterMessageID,
+		)
 		time.Sleep(time.Second)
 	}
 
-	// Get the latest block height
-	currentBlockHeight, err := destination.RPCClient.BlockNumber(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	var startBlock uint64
-	if currentBlockHeight > receiveCrossChainMessageLookBackBlocks {
-		startBlock = currentBlockHeight - receiveCrossChainMessageLookBackBlocks
-	} else {
-		startBlock = 0
-	}
-
-	abi, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	startBlock, _, err := teleporterUtils.LookBackStartBlock(
+		ctx, destination.RPCClient, receiveCrossChainMessageLookBackBlocks,
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get the log event of the delivery. The log must be in the last {receiveCrossChainMessageLookBackBlocks} blocks.
-	logs, err := destination.RPCClient.FilterLogs(ctx, subnetevminterfaces.FilterQuery{
-		FromBlock: big.NewInt(int64(startBlock)),
-		Addresses: []common.Address{n.teleporterContractAddress},
-		Topics: [][]common.Hash{
-			{abi.Events[receiveCrossChainMessageEventName].ID},
-			{common.BytesToHash(sourceBlockchainID[:])},
-			{common.BytesToHash(teleporterMessageID[:])},
-		},
-	})
+	deliveryLog, err := teleporterUtils.GetMessageDeliveryLog(
+		ctx, destination.RPCClient, n.teleporterContractAddress, sourceBlockchainID, teleporterMessageID, startBlock,
+	)
 	if err != nil {
 		return nil, err
 	}
-
-	if len(logs) == 0 {
+	if deliveryLog == nil {
 		return nil, errors.New("Failed to find ReceiveCrossChainMessage log for relayed message")
-	} else if len(logs) > 1 {
-		return nil, errors.New("Found multiple ReceiveCrossChainMessage logs for relayed message")
 	}
 
-	return destination.RPCClient.TransactionReceipt(ctx, logs[0].TxHash)
+	return destination.RPCClient.TransactionReceipt(ctx, deliveryLog.TxHash)
 }
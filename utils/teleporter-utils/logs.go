@@ -0,0 +1,77 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+)
+
+const (
+	// DefaultFilterLogsChunkSize is the default number of blocks queried per eth_getLogs call.
+	// Public RPC endpoints commonly reject ranges larger than this.
+	DefaultFilterLogsChunkSize uint64 = 2048
+)
+
+var errInvalidChunkSize = errors.New("chunk size must be non-zero")
+
+// FilterLogs returns the logs matching the query's addresses and topics between fromBlock and toBlock
+// inclusive. The range is queried in chunks of at most chunkSize blocks to respect RPC range limits.
+// The query's FromBlock, ToBlock and BlockHash fields are ignored.
+func FilterLogs(
+	ctx context.Context,
+	client ethclient.Client,
+	query interfaces.FilterQuery,
+	fromBlock uint64,
+	toBlock uint64,
+	chunkSize uint64,
+) ([]types.Log, error) {
+	if chunkSize == 0 {
+		return nil, errInvalidChunkSize
+	}
+
+	var logs []types.Log
+	for start := fromBlock; start <= toBlock; start += chunkSize {
+		end := start + chunkSize - 1
+		if end > toBlock || end < start {
+			end = toBlock
+		}
+
+		chunkQuery := interfaces.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: query.Addresses,
+			Topics:    query.Topics,
+		}
+		chunkLogs, err := client.FilterLogs(ctx, chunkQuery)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, chunkLogs...)
+
+		// Guard against overflow when the range ends at the maximum block number.
+		if end == toBlock {
+			break
+		}
+	}
+	return logs, nil
+}
+
+// LookBackStartBlock returns the block number lookBackBlocks before the latest block, or zero if
+// the chain is shorter than lookBackBlocks, along with the latest block number.
+func LookBackStartBlock(ctx context.Context, client ethclient.Client, lookBackBlocks uint64) (uint64, uint64, error) {
+	latest, err := client.BlockNumber(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	if latest > lookBackBlocks {
+		return latest - lookBackBlocks, latest, nil
+	}
+	return 0, latest, nil
+}
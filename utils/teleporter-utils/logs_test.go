@@ -0,0 +1,211 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// ethClient names the embedded interface of fakeLogsClient, since a field named Client would
+// conflict with the Client method of ethclient.Client.
+type ethClient = ethclient.Client
+
+// fakeLogsClient is an ethclient.Client that records the log queries it receives, and returns one
+// log per query at its first block.
+type fakeLogsClient struct {
+	ethClient
+	latest  uint64
+	queries []interfaces.FilterQuery
+}
+
+func (c *fakeLogsClient) BlockNumber(context.Context) (uint64, error) {
+	return c.latest, nil
+}
+
+func (c *fakeLogsClient) FilterLogs(_ context.Context, query interfaces.FilterQuery) ([]types.Log, error) {
+	c.queries = append(c.queries, query)
+	return []types.Log{{BlockNumber: query.FromBlock.Uint64()}}, nil
+}
+
+// ranges returns the block ranges of the recorded queries.
+func (c *fakeLogsClient) ranges() [][2]uint64 {
+	var ranges [][2]uint64
+	for _, query := range c.queries {
+		ranges = append(ranges, [2]uint64{query.FromBlock.Uint64(), query.ToBlock.Uint64()})
+	}
+	return ranges
+}
+
+func TestFilterLogs(t *testing.T) {
+	tests := []struct {
+		name      string
+		fromBlock uint64
+		toBlock   uint64
+		chunkSize uint64
+		ranges    [][2]uint64
+		err       error
+	}{
+		{
+			name:      "single block",
+			fromBlock: 5,
+			toBlock:   5,
+			chunkSize: 10,
+			ranges:    [][2]uint64{{5, 5}},
+		},
+		{
+			name:      "multiple of chunk size",
+			fromBlock: 0,
+			toBlock:   7,
+			chunkSize: 4,
+			ranges:    [][2]uint64{{0, 3}, {4, 7}},
+		},
+		{
+			name:      "not a multiple of chunk size",
+			fromBlock: 0,
+			toBlock:   9,
+			chunkSize: 4,
+			ranges:    [][2]uint64{{0, 3}, {4, 7}, {8, 9}},
+		},
+		{
+			name:      "chunk size of one",
+			fromBlock: 3,
+			toBlock:   5,
+			chunkSize: 1,
+			ranges:    [][2]uint64{{3, 3}, {4, 4}, {5, 5}},
+		},
+		{
+			name:      "empty range",
+			fromBlock: 6,
+			toBlock:   5,
+			chunkSize: 4,
+		},
+		{
+			name:      "ends at maximum block number",
+			fromBlock: math.MaxUint64 - 5,
+			toBlock:   math.MaxUint64,
+			chunkSize: 4,
+			ranges:    [][2]uint64{{math.MaxUint64 - 5, math.MaxUint64 - 2}, {math.MaxUint64 - 1, math.MaxUint64}},
+		},
+		{
+			name:      "zero chunk size",
+			fromBlock: 0,
+			toBlock:   9,
+			chunkSize: 0,
+			err:       errInvalidChunkSize,
+		},
+	}
+
+	query := interfaces.FilterQuery{
+		Addresses: []common.Address{{1}},
+		Topics:    [][]common.Hash{{{2}}, nil, {{3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeLogsClient{}
+			logs, err := FilterLogs(context.Background(), client, query, tt.fromBlock, tt.toBlock, tt.chunkSize)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.ranges, client.ranges())
+
+			// The logs of all chunks are returned in order, for the addresses and topics of the query.
+			require.Len(t, logs, len(tt.ranges))
+			for i, log := range logs {
+				require.Equal(t, tt.ranges[i][0], log.BlockNumber)
+				require.Equal(t, query.Addresses, client.queries[i].Addresses)
+				require.Equal(t, query.Topics, client.queries[i].Topics)
+			}
+		})
+	}
+}
+
+func TestLookBackStartBlock(t *testing.T) {
+	tests := []struct {
+		name           string
+		latest         uint64
+		lookBackBlocks uint64
+		startBlock     uint64
+	}{
+		{
+			name:           "look back",
+			latest:         100,
+			lookBackBlocks: 10,
+			startBlock:     90,
+		},
+		{
+			name:           "clamped at genesis",
+			latest:         5,
+			lookBackBlocks: 10,
+			startBlock:     0,
+		},
+		{
+			name:           "look back to genesis",
+			latest:         10,
+			lookBackBlocks: 10,
+			startBlock:     0,
+		},
+		{
+			name:           "genesis",
+			latest:         0,
+			lookBackBlocks: 10,
+			startBlock:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startBlock, latest, err := LookBackStartBlock(
+				context.Background(), &fakeLogsClient{latest: tt.latest}, tt.lookBackBlocks,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tt.startBlock, startBlock)
+			require.Equal(t, tt.latest, latest)
+		})
+	}
+}
+
+func TestFilterMessageLogs(t *testing.T) {
+	teleporterABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+	teleporterAddress := common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+	messageIDTopic := []common.Hash{{1}}
+
+	// The range up to the latest block is queried in chunks of the default size.
+	client := &fakeLogsClient{latest: 2*DefaultFilterLogsChunkSize + 10}
+	logs, err := filterMessageLogs(
+		context.Background(), client, teleporterAddress, "MessageExecuted", [][]common.Hash{messageIDTopic}, 1,
+	)
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+	require.Equal(t, [][2]uint64{
+		{1, DefaultFilterLogsChunkSize},
+		{DefaultFilterLogsChunkSize + 1, 2 * DefaultFilterLogsChunkSize},
+		{2*DefaultFilterLogsChunkSize + 1, 2*DefaultFilterLogsChunkSize + 10},
+	}, client.ranges())
+	for _, query := range client.queries {
+		require.Equal(t, []common.Address{teleporterAddress}, query.Addresses)
+		require.Equal(t, [][]common.Hash{{teleporterABI.Events["MessageExecuted"].ID}, messageIDTopic}, query.Topics)
+	}
+
+	// A start block after the latest block queries nothing.
+	client = &fakeLogsClient{latest: 5}
+	logs, err = filterMessageLogs(context.Background(), client, teleporterAddress, "MessageExecuted", nil, 6)
+	require.NoError(t, err)
+	require.Empty(t, logs)
+	require.Empty(t, client.queries)
+
+	// From genesis to genesis is a single block.
+	client = &fakeLogsClient{latest: 0}
+	logs, err = filterMessageLogs(context.Background(), client, teleporterAddress, "MessageExecuted", nil, 0)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, [][2]uint64{{0, 0}}, client.ranges())
+}
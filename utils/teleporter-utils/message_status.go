@@ -0,0 +1,438 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// DefaultLookBackBlocks is the default number of blocks searched for Teleporter logs
+	// when the block a message was sent or delivered in is not known.
+	DefaultLookBackBlocks uint64 = 500
+)
+
+var (
+	ErrSendEventNotFound            = errors.New("failed to find SendCrossChainMessage log")
+	ErrExecutionFailedEventNotFound = errors.New("failed to find MessageExecutionFailed log")
+	ErrMultipleDeliveries           = errors.New("found multiple ReceiveCrossChainMessage logs for message")
+)
+
+// MessageStatus describes how far a Teleporter message has progressed from its source chain
+// to its destination chain, and back to the source chain by way of its receipt.
+type MessageStatus struct {
+	MessageID               ids.ID
+	SourceBlockchainID      ids.ID
+	DestinationBlockchainID ids.ID
+
+	// Sent is true if the SendCrossChainMessage log for the message was found on the source chain.
+	Sent       bool
+	SendTxHash common.Hash
+
+	// Delivered is true if the destination chain reports the message as received. DeliveryTxHash
+	// is only set if the ReceiveCrossChainMessage log was found within the searched block range.
+	Delivered      bool
+	DeliveryTxHash common.Hash
+
+	// Executed is true if a MessageExecuted log was found on the destination chain, either from the
+	// initial delivery or a later retry. ExecutionFailed is true if a MessageExecutionFailed log was
+	// found, in which case the message may still be executed by calling retryMessageExecution.
+	Executed        bool
+	ExecutionFailed bool
+	ExecutionTxHash common.Hash
+
+	// ReceiptReceived is true if the ReceiptReceived log for the message was found on the source chain.
+	ReceiptReceived bool
+	ReceiptTxHash   common.Hash
+}
+
+// GetSendCrossChainMessageEvent returns the SendCrossChainMessage event emitted by the Teleporter
+// contract in the given transaction, along with the block number the transaction was included in.
+func GetSendCrossChainMessageEvent(
+	ctx context.Context,
+	client ethclient.Client,
+	teleporterAddress common.Address,
+	txHash common.Hash,
+) (*teleportermessenger.TeleporterMessengerSendCrossChainMessage, uint64, error) {
+	receipt, err := client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filterer, err := teleportermessenger.NewTeleporterMessengerFilterer(teleporterAddress, client)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, log := range receipt.Logs {
+		if log.Address != teleporterAddress {
+			continue
+		}
+		event, err := filterer.ParseSendCrossChainMessage(*log)
+		if err == nil {
+			return event, receipt.BlockNumber.Uint64(), nil
+		}
+	}
+	return nil, 0, ErrSendEventNotFound
+}
+
+// GetSendCrossChainMessageEventByID returns the first SendCrossChainMessage event emitted for the given
+// message on the source chain since fromBlock. A message's SendCrossChainMessage event is emitted again
+// each time its send is retried, and each emission carries the same message.
+func GetSendCrossChainMessageEventByID(
+	ctx context.Context,
+	source ethclient.Client,
+	teleporterAddress common.Address,
+	messageID ids.ID,
+	fromBlock uint64,
+) (*teleportermessenger.TeleporterMessengerSendCrossChainMessage, error) {
+	logs, err := filterMessageLogs(
+		ctx,
+		source,
+		teleporterAddress,
+		"SendCrossChainMessage",
+		[][]common.Hash{{common.Hash(messageID)}},
+		fromBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) == 0 {
+		return nil, ErrSendEventNotFound
+	}
+
+	filterer, err := teleportermessenger.NewTeleporterMessengerFilterer(teleporterAddress, source)
+	if err != nil {
+		return nil, err
+	}
+	return filterer.ParseSendCrossChainMessage(logs[0])
+}
+
+// GetMessageExecutionFailedEvent returns the MessageExecutionFailed event emitted for the
+// given message on the destination chain since fromBlock.
+func GetMessageExecutionFailedEvent(
+	ctx context.Context,
+	destination ethclient.Client,
+	teleporterAddress common.Address,
+	messageID ids.ID,
+	fromBlock uint64,
+) (*teleportermessenger.TeleporterMessengerMessageExecutionFailed, error) {
+	logs, err := filterMessageLogs(
+		ctx,
+		destination,
+		teleporterAddress,
+		"MessageExecutionFailed",
+		[][]common.Hash{{common.Hash(messageID)}},
+		fromBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) == 0 {
+		return nil, ErrExecutionFailedEventNotFound
+	}
+
+	filterer, err := teleportermessenger.NewTeleporterMessengerFilterer(teleporterAddress, destination)
+	if err != nil {
+		return nil, err
+	}
+	return filterer.ParseMessageExecutionFailed(logs[0])
+}
+
+// CheckMessageDelivered returns true if the Teleporter contract on the destination chain has
+// received the given message.
+func CheckMessageDelivered(
+	ctx context.Context,
+	destination ethclient.Client,
+	teleporterAddress common.Address,
+	messageID ids.ID,
+) (bool, error) {
+	messenger, err := teleportermessenger.NewTeleporterMessengerCaller(teleporterAddress, destination)
+	if err != nil {
+		return false, err
+	}
+	return messenger.MessageReceived(&bind.CallOpts{Context: ctx}, messageID)
+}
+
+// GetMessageDeliveryLog returns the ReceiveCrossChainMessage log for the given message on the destination
+// chain, searching from fromBlock to the latest block. Returns nil if no such log is found.
+func GetMessageDeliveryLog(
+	ctx context.Context,
+	destination ethclient.Client,
+	teleporterAddress common.Address,
+	sourceBlockchainID ids.ID,
+	messageID ids.ID,
+	fromBlock uint64,
+) (*types.Log, error) {
+	logs, err := filterMessageLogs(
+		ctx,
+		destination,
+		teleporterAddress,
+		"ReceiveCrossChainMessage",
+		[][]common.Hash{{common.Hash(messageID)}, {common.Hash(sourceBlockchainID)}},
+		fromBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(logs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &logs[0], nil
+	default:
+		return nil, ErrMultipleDeliveries
+	}
+}
+
+// GetMessageStatus reports the status of the message with the given ID sent from the source chain
+// to the destination chain, whose Teleporter contracts may be deployed at different addresses. The
+// SendCrossChainMessage log is searched for in the last lookBackBlocks blocks of the source chain.
+func GetMessageStatus(
+	ctx context.Context,
+	source ethclient.Client,
+	destination ethclient.Client,
+	sourceTeleporterAddress common.Address,
+	destinationTeleporterAddress common.Address,
+	messageID ids.ID,
+	lookBackBlocks uint64,
+) (*MessageStatus, error) {
+	sourceStartBlock, _, err := LookBackStartBlock(ctx, source, lookBackBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := filterMessageLogs(
+		ctx,
+		source,
+		sourceTeleporterAddress,
+		"SendCrossChainMessage",
+		[][]common.Hash{{common.Hash(messageID)}},
+		sourceStartBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var sendEvent *teleportermessenger.TeleporterMessengerSendCrossChainMessage
+	if len(logs) > 0 {
+		filterer, err := teleportermessenger.NewTeleporterMessengerFilterer(sourceTeleporterAddress, source)
+		if err != nil {
+			return nil, err
+		}
+		sendEvent, err = filterer.ParseSendCrossChainMessage(logs[0])
+		if err != nil {
+			return nil, err
+		}
+		sourceStartBlock = logs[0].BlockNumber
+	}
+
+	return getMessageStatus(
+		ctx,
+		source,
+		destination,
+		sourceTeleporterAddress,
+		destinationTeleporterAddress,
+		messageID,
+		sendEvent,
+		sourceStartBlock,
+		lookBackBlocks,
+	)
+}
+
+// GetMessageStatusFromTransaction reports the status of the message sent from the source chain in the
+// transaction with the given hash. The Teleporter contracts of the source and destination chains may
+// be deployed at different addresses.
+func GetMessageStatusFromTransaction(
+	ctx context.Context,
+	source ethclient.Client,
+	destination ethclient.Client,
+	sourceTeleporterAddress common.Address,
+	destinationTeleporterAddress common.Address,
+	txHash common.Hash,
+	lookBackBlocks uint64,
+) (*MessageStatus, error) {
+	sendEvent, sendBlock, err := GetSendCrossChainMessageEvent(ctx, source, sourceTeleporterAddress, txHash)
+	if err != nil {
+		return nil, err
+	}
+	return getMessageStatus(
+		ctx,
+		source,
+		destination,
+		sourceTeleporterAddress,
+		destinationTeleporterAddress,
+		sendEvent.MessageID,
+		sendEvent,
+		sendBlock,
+		lookBackBlocks,
+	)
+}
+
+func getMessageStatus(
+	ctx context.Context,
+	source ethclient.Client,
+	destination ethclient.Client,
+	sourceTeleporterAddress common.Address,
+	destinationTeleporterAddress common.Address,
+	messageID ids.ID,
+	sendEvent *teleportermessenger.TeleporterMessengerSendCrossChainMessage,
+	sourceStartBlock uint64,
+	lookBackBlocks uint64,
+) (*MessageStatus, error) {
+	status := &MessageStatus{
+		MessageID: messageID,
+	}
+
+	sourceMessenger, err := teleportermessenger.NewTeleporterMessengerCaller(sourceTeleporterAddress, source)
+	if err != nil {
+		return nil, err
+	}
+	status.SourceBlockchainID, err = sourceMessenger.BlockchainID(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source blockchain ID: %w", err)
+	}
+
+	destinationMessenger, err := teleportermessenger.NewTeleporterMessengerCaller(
+		destinationTeleporterAddress,
+		destination,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if sendEvent != nil {
+		status.Sent = true
+		status.SendTxHash = sendEvent.Raw.TxHash
+		status.DestinationBlockchainID = sendEvent.DestinationBlockchainID
+	} else {
+		status.DestinationBlockchainID, err = destinationMessenger.BlockchainID(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get destination blockchain ID: %w", err)
+		}
+	}
+
+	status.Delivered, err = destinationMessenger.MessageReceived(&bind.CallOpts{Context: ctx}, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check message delivery: %w", err)
+	}
+
+	if status.Delivered {
+		destinationStartBlock, _, err := LookBackStartBlock(ctx, destination, lookBackBlocks)
+		if err != nil {
+			return nil, err
+		}
+		deliveryLog, err := GetMessageDeliveryLog(
+			ctx, destination, destinationTeleporterAddress, status.SourceBlockchainID, messageID, destinationStartBlock,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if deliveryLog != nil {
+			status.DeliveryTxHash = deliveryLog.TxHash
+			destinationStartBlock = deliveryLog.BlockNumber
+		}
+
+		err = setExecutionStatus(ctx, destination, destinationTeleporterAddress, status, destinationStartBlock)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	receiptLogs, err := filterMessageLogs(
+		ctx,
+		source,
+		sourceTeleporterAddress,
+		"ReceiptReceived",
+		[][]common.Hash{{common.Hash(messageID)}},
+		sourceStartBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(receiptLogs) > 0 {
+		status.ReceiptReceived = true
+		status.ReceiptTxHash = receiptLogs[0].TxHash
+	}
+
+	return status, nil
+}
+
+// setExecutionStatus sets the execution fields of the status from the MessageExecuted and
+// MessageExecutionFailed logs emitted on the destination chain since fromBlock.
+func setExecutionStatus(
+	ctx context.Context,
+	destination ethclient.Client,
+	teleporterAddress common.Address,
+	status *MessageStatus,
+	fromBlock uint64,
+) error {
+	failedLogs, err := filterMessageLogs(
+		ctx,
+		destination,
+		teleporterAddress,
+		"MessageExecutionFailed",
+		[][]common.Hash{{common.Hash(status.MessageID)}},
+		fromBlock,
+	)
+	if err != nil {
+		return err
+	}
+	if len(failedLogs) > 0 {
+		status.ExecutionFailed = true
+		status.ExecutionTxHash = failedLogs[0].TxHash
+	}
+
+	executedLogs, err := filterMessageLogs(
+		ctx,
+		destination,
+		teleporterAddress,
+		"MessageExecuted",
+		[][]common.Hash{{common.Hash(status.MessageID)}},
+		fromBlock,
+	)
+	if err != nil {
+		return err
+	}
+	if len(executedLogs) > 0 {
+		status.Executed = true
+		status.ExecutionTxHash = executedLogs[0].TxHash
+	}
+	return nil
+}
+
+// filterMessageLogs returns the logs of the given Teleporter event emitted since fromBlock
+// matching the provided indexed topics.
+func filterMessageLogs(
+	ctx context.Context,
+	client ethclient.Client,
+	teleporterAddress common.Address,
+	eventName string,
+	indexedTopics [][]common.Hash,
+	fromBlock uint64,
+) ([]types.Log, error) {
+	teleporterABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return FilterLogs(ctx, client, interfaces.FilterQuery{
+		Addresses: []common.Address{teleporterAddress},
+		Topics:    append([][]common.Hash{{teleporterABI.Events[eventName].ID}}, indexedTopics...),
+	}, fromBlock, latest, DefaultFilterLogsChunkSize)
+}